@@ -7,7 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"sync"
+	"time"
 )
 
 var (
@@ -16,13 +19,32 @@ var (
 )
 
 type Api struct {
-	key string
+	key     string
+	retries int
+	metrics Metrics
+	logger  Logger
+
+	mu   sync.Mutex
+	next time.Time
 }
 
+// apiRetryBackoff is the delay before the first retry, it doubles with every
+// next one.
+var apiRetryBackoff = 100 * time.Millisecond
+
 func NewApi(apiKey string) *Api {
 	return &Api{key: apiKey}
 }
 
+// WithApiRetries retries random.org requests that could not be sent, because
+// the host could not be resolved or connected to, up to retries times with a
+// backoff starting at 100ms.
+func WithApiRetries(retries int) Option {
+	return func(l *Logic) {
+		l.api.retries = retries
+	}
+}
+
 var (
 	marshal   = json.Marshal
 	marshal1  = json.Marshal
@@ -35,6 +57,159 @@ type ApiError struct {
 	Data    map[string]interface{} `json:"data"`
 }
 
+type apiResponse interface {
+	apiError() *ApiError
+	// logArgs returns the fields of the response that can be logged without
+	// revealing the random data.
+	logArgs() []interface{}
+}
+
+// invoke calls the JSON-RPC method, retrying up to api.retries times with a
+// backoff when the request could not be sent at all. A request that may have
+// reached random.org is never repeated, as a repeated generateSigned* request
+// would draw a second signed result.
+func (api *Api) invoke(ctx context.Context, method string, requestData interface{}, responseData apiResponse) error {
+	startedAt := time.Now()
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = sleep(ctx, api.delay()); err != nil {
+			break
+		}
+
+		var retry bool
+		retry, err = api.do(ctx, method, requestData, responseData)
+		if !retry || attempt >= api.retries {
+			break
+		}
+
+		if api.logger != nil {
+			api.logger.Warn("random.org request retry", "method", method, "attempt", attempt+1, "error", err)
+		}
+		if api.metrics != nil {
+			api.metrics.IncApiRetry(method)
+		}
+
+		if sleepErr := sleep(ctx, apiRetryBackoff<<uint(attempt)); sleepErr != nil {
+			break
+		}
+	}
+
+	if api.metrics != nil {
+		outcome := "ok"
+		if _, ok := err.(*ApiError); ok {
+			outcome = "api_error"
+		} else if err != nil {
+			outcome = "error"
+		}
+		api.metrics.ObserveApiRequest(method, outcome, time.Since(startedAt))
+	}
+	if err != nil && api.logger != nil {
+		api.logger.Error("random.org request failed", "method", method, "duration", time.Since(startedAt), "error", err)
+	}
+	return err
+}
+
+// notSent reports whether a request failed before any of it was sent, while
+// resolving or connecting to the host.
+func notSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// delay returns how long to wait before the next request to honour the
+// advisoryDelay of the last response.
+func (api *Api) delay() time.Duration {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	return time.Until(api.next)
+}
+
+// advise holds the next request back by advisoryDelay milliseconds, as
+// random.org asks its clients to.
+func (api *Api) advise(advisoryDelay int) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.next = time.Now().Add(time.Duration(advisoryDelay) * time.Millisecond)
+}
+
+func (api *Api) do(ctx context.Context, method string, requestData interface{}, responseData apiResponse) (bool, error) {
+	requestBytes, err := marshal(requestData)
+	if err != nil {
+		return false, err
+	}
+
+	if api.logger != nil {
+		api.logger.Debug("random.org request", "method", method)
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		ApiMethod,
+		ApiUrl,
+		bytes.NewBuffer(requestBytes),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	response, err := client.Do(request)
+	if err != nil {
+		return notSent(err), err
+	}
+	defer response.Body.Close()
+	body, _ := ioutil.ReadAll(response.Body)
+
+	err = unmarshal(body, responseData)
+	if api.logger != nil {
+		args := []interface{}{"method", method, "status", response.StatusCode}
+		if err == nil {
+			args = append(args, responseData.logArgs()...)
+		}
+		api.logger.Debug("random.org response", args...)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if apiErr := responseData.apiError(); apiErr != nil {
+		return false, apiErr
+	}
+	return false, nil
+}
+
+func (api *Api) observeUsage(bitsLeft int, requestsLeft int) {
+	if api.metrics != nil {
+		api.metrics.SetApiUsage(bitsLeft, requestsLeft)
+	}
+}
+
 func (err *ApiError) Error() string {
 	return fmt.Sprintf("error %d: %s", err.Code, err.Message)
 }
@@ -79,10 +254,10 @@ type decimalResponseResult struct {
 	Random        decimalResponseRandom `json:"random"`
 	Signature     string                `json:"signature"`
 	Cost          float64               `json:"cost"`
-	BitsUsed      int                   `json:"bits_used"`
-	BitsLeft      int                   `json:"bits_left"`
-	RequestsLeft  int                   `json:"requests_left"`
-	AdvisoryDelay int                   `json:"advisory_delay"`
+	BitsUsed      int                   `json:"bitsUsed"`
+	BitsLeft      int                   `json:"bitsLeft"`
+	RequestsLeft  int                   `json:"requestsLeft"`
+	AdvisoryDelay int                   `json:"advisoryDelay"`
 }
 
 type decimalResponse struct {
@@ -93,12 +268,27 @@ type decimalResponse struct {
 	ID      int                    `json:"id"`
 }
 
+func (r *decimalResponse) apiError() *ApiError {
+	return r.Error
+}
+
+func (r *decimalResponse) logArgs() []interface{} {
+	if r.Result == nil {
+		return nil
+	}
+	return []interface{}{
+		"serialNumber", r.Result.Random.SerialNumber,
+		"bitsLeft", r.Result.BitsLeft,
+		"requestsLeft", r.Result.RequestsLeft,
+	}
+}
+
 func (api *Api) GenerateDecimal(ctx context.Context, decimalPlaces uint) (*Decimal, error) {
 	if decimalPlaces == 0 || decimalPlaces > 8 {
 		decimalPlaces = 8
 	}
 
-	requestData := decimalRequest{
+	requestData := &decimalRequest{
 		JsonRPC: "2.0",
 		Method:  "generateSignedDecimalFractions",
 		Params: decimalRequestParams{
@@ -109,50 +299,22 @@ func (api *Api) GenerateDecimal(ctx context.Context, decimalPlaces uint) (*Decim
 		ID: 1337,
 	}
 
-	requestBytes, err := marshal(requestData)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequestWithContext(
-		ctx,
-		ApiMethod,
-		ApiUrl,
-		bytes.NewBuffer(requestBytes),
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	request.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	response, err := client.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	defer response.Body.Close()
-	body, _ := ioutil.ReadAll(response.Body)
-
 	responseData := &decimalResponse{}
-	err = unmarshal(body, responseData)
-	if err != nil {
+	if err := api.invoke(ctx, requestData.Method, requestData, responseData); err != nil {
 		return nil, err
 	}
 
-	if responseData.Error != nil {
-		return nil, responseData.Error
+	if responseData.Result == nil || len(responseData.Result.Random.Data) == 0 {
+		return nil, errors.New("wrong response")
 	}
+	api.observeUsage(responseData.Result.BitsLeft, responseData.Result.RequestsLeft)
+	api.advise(responseData.Result.AdvisoryDelay)
 
 	randomBytes, err := marshal1(responseData.Result.Random)
 	if err != nil {
 		return nil, err
 	}
 
-	if responseData.Result == nil || len(responseData.Result.Random.Data) == 0 {
-		return nil, errors.New("wrong response")
-	}
-
 	decimal := &Decimal{
 		Value:        responseData.Result.Random.Data[0],
 		Random:       string(randomBytes),
@@ -219,6 +381,21 @@ type integerResponse struct {
 	ID      int                    `json:"id"`
 }
 
+func (r *integerResponse) apiError() *ApiError {
+	return r.Error
+}
+
+func (r *integerResponse) logArgs() []interface{} {
+	if r.Result == nil {
+		return nil
+	}
+	return []interface{}{
+		"serialNumber", r.Result.Random.SerialNumber,
+		"bitsLeft", r.Result.BitsLeft,
+		"requestsLeft", r.Result.RequestsLeft,
+	}
+}
+
 func (api *Api) GenerateInteger(ctx context.Context, min int, max int) (*Integer, error) {
 	requestData := &integerRequest{
 		JsonRPC: "2.0",
@@ -231,50 +408,22 @@ func (api *Api) GenerateInteger(ctx context.Context, min int, max int) (*Integer
 		},
 	}
 
-	requestBytes, err := marshal(requestData)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequestWithContext(
-		ctx,
-		ApiMethod,
-		ApiUrl,
-		bytes.NewBuffer(requestBytes),
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	request.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	response, err := client.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	defer response.Body.Close()
-	body, _ := ioutil.ReadAll(response.Body)
-
 	responseData := &integerResponse{}
-	err = unmarshal(body, responseData)
-	if err != nil {
+	if err := api.invoke(ctx, requestData.Method, requestData, responseData); err != nil {
 		return nil, err
 	}
 
-	if responseData.Error != nil {
-		return nil, responseData.Error
+	if responseData.Result == nil || len(responseData.Result.Random.Data) == 0 {
+		return nil, errors.New("wrong response")
 	}
+	api.observeUsage(responseData.Result.BitsLeft, responseData.Result.RequestsLeft)
+	api.advise(responseData.Result.AdvisoryDelay)
 
 	randomBytes, err := marshal1(responseData.Result.Random)
 	if err != nil {
 		return nil, err
 	}
 
-	if responseData.Result == nil || len(responseData.Result.Random.Data) == 0 {
-		return nil, errors.New("wrong response")
-	}
-
 	integer := &Integer{
 		Value:        responseData.Result.Random.Data[0],
 		Random:       string(randomBytes),
@@ -284,3 +433,57 @@ func (api *Api) GenerateInteger(ctx context.Context, min int, max int) (*Integer
 
 	return integer, nil
 }
+
+type signatureRequestParams struct {
+	Random    json.RawMessage `json:"random"`
+	Signature string          `json:"signature"`
+}
+
+type signatureRequest struct {
+	JsonRPC string                 `json:"jsonrpc"`
+	Method  string                 `json:"method"`
+	Params  signatureRequestParams `json:"params"`
+	ID      int                    `json:"id"`
+}
+
+type signatureResponseResult struct {
+	Authenticity bool `json:"authenticity"`
+}
+
+type signatureResponse struct {
+	JsonRPC string                   `json:"jsonrpc"`
+	Result  *signatureResponseResult `json:"result"`
+	Error   *ApiError                `json:"error"`
+	ID      int                      `json:"id"`
+}
+
+func (r *signatureResponse) apiError() *ApiError {
+	return r.Error
+}
+
+func (r *signatureResponse) logArgs() []interface{} {
+	return nil
+}
+
+func (api *Api) VerifySignature(ctx context.Context, random string, signature string) (bool, error) {
+	requestData := &signatureRequest{
+		JsonRPC: "2.0",
+		Method:  "verifySignature",
+		Params: signatureRequestParams{
+			Random:    json.RawMessage(random),
+			Signature: signature,
+		},
+		ID: 1337,
+	}
+
+	responseData := &signatureResponse{}
+	if err := api.invoke(ctx, requestData.Method, requestData, responseData); err != nil {
+		return false, err
+	}
+
+	if responseData.Result == nil {
+		return false, errors.New("wrong response")
+	}
+
+	return responseData.Result.Authenticity, nil
+}
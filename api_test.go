@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestApi_GenerateDecimalWrongMarshal(t *testing.T) {
@@ -167,3 +171,86 @@ func TestApi_GenerateInteger(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestApi_NoRetryAfterSend(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn.Close()
+	}))
+	url := ApiUrl
+	ApiUrl = server.URL
+	defer func() {
+		ApiUrl = url
+		server.Close()
+	}()
+
+	api := NewApi(os.Getenv("API_KEY"))
+	api.retries = 2
+	if _, err := api.GenerateInteger(context.Background(), 0, 53); err == nil {
+		t.Fatalf("Expected error but got nil")
+	}
+	if count := atomic.LoadInt32(&requests); count != 1 {
+		t.Fatalf("expected 1 request, but got %d", count)
+	}
+}
+
+func TestApi_RetryBackoff(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	url := ApiUrl
+	ApiUrl = server.URL
+	defer func() { ApiUrl = url }()
+
+	api := NewApi(os.Getenv("API_KEY"))
+	api.retries = 2
+	startedAt := time.Now()
+	if _, err := api.GenerateInteger(context.Background(), 0, 53); err == nil {
+		t.Fatalf("Expected error but got nil")
+	}
+	if elapsed := time.Since(startedAt); elapsed < 3*apiRetryBackoff {
+		t.Fatalf("expected at least %v, but got %v", 3*apiRetryBackoff, elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiRetryBackoff/2)
+	defer cancel()
+	api.retries = 100
+	startedAt = time.Now()
+	if _, err := api.GenerateInteger(ctx, 0, 53); err == nil {
+		t.Fatalf("Expected error but got nil")
+	}
+	if elapsed := time.Since(startedAt); elapsed >= apiRetryBackoff {
+		t.Fatalf("expected to stop with the context, but got %v", elapsed)
+	}
+}
+
+func TestApi_AdvisoryDelay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":{"random":{"method":"generateSignedIntegers","n":1,"min":0,"max":53,"data":[7],"serialNumber":42},` +
+			`"signature":"signature","bitsUsed":6,"bitsLeft":249994,"requestsLeft":999,"advisoryDelay":200},"id":1337}`))
+	}))
+	url := ApiUrl
+	ApiUrl = server.URL
+	defer func() {
+		ApiUrl = url
+		server.Close()
+	}()
+
+	api := NewApi(os.Getenv("API_KEY"))
+	if _, err := api.GenerateInteger(context.Background(), 0, 53); err != nil {
+		t.Fatal(err)
+	}
+
+	startedAt := time.Now()
+	if _, err := api.GenerateInteger(context.Background(), 0, 53); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(startedAt); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected the advisory delay of 200ms, but got %v", elapsed)
+	}
+}
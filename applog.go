@@ -0,0 +1,89 @@
+package logic
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+)
+
+// appendLog is a file of JSON lines that is only appended to and synced to
+// disk on every write. It is not safe for concurrent use.
+type appendLog struct {
+	file *os.File
+}
+
+// openAppendLog opens the file and passes every line in it to replay along
+// with its offset. A last line without a newline is a write torn by a crash
+// and is cut off.
+func openAppendLog(path string, replay func(offset int64, line []byte) error) (*appendLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	log := &appendLog{file: file}
+	if err := log.load(replay); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return log, nil
+}
+
+func (a *appendLog) load(replay func(offset int64, line []byte) error) error {
+	reader := bufio.NewReader(a.file)
+
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				if err := a.file.Truncate(offset); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			if err := replay(offset, trimmed); err != nil {
+				return err
+			}
+		}
+		offset += int64(len(line))
+	}
+
+	_, err := a.file.Seek(offset, io.SeekStart)
+	return err
+}
+
+// append writes v as a line and syncs the file. On failure the file is cut
+// back, so that a partial line is not followed by the next one.
+func (a *appendLog) append(v interface{}) error {
+	data, err := marshal(v)
+	if err != nil {
+		return err
+	}
+
+	offset, err := a.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	if _, err = a.file.Write(append(data, '\n')); err == nil {
+		err = a.file.Sync()
+	}
+	if err != nil {
+		_ = a.file.Truncate(offset)
+		_, _ = a.file.Seek(offset, io.SeekStart)
+		return err
+	}
+	return nil
+}
+
+func (a *appendLog) Close() error {
+	return a.file.Close()
+}
@@ -0,0 +1,269 @@
+package logic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// AuditEntry records a single generation call. Hash is the SHA-512 of
+// PrevHash followed by the JSON of the entry with an empty Hash, so editing or
+// removing an entry breaks every hash after it. Output holds the encoded
+// result including unrevealed seeds, so the log must be kept private.
+type AuditEntry struct {
+	Sequence   uint64          `json:"sequence"`
+	Call       string          `json:"call"`
+	Source     string          `json:"source"`
+	Input      json.RawMessage `json:"input,omitempty"`
+	Output     json.RawMessage `json:"output,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt time.Time       `json:"finishedAt"`
+	PrevHash   string          `json:"prevHash"`
+	Hash       string          `json:"hash"`
+}
+
+// AuditSink stores audit entries. LastAuditEntry returns the last stored
+// entry, or nil, so that the chain continues after a restart.
+type AuditSink interface {
+	WriteAuditEntry(entry *AuditEntry) error
+	LastAuditEntry() (*AuditEntry, error)
+}
+
+func WithAuditSink(sink AuditSink) Option {
+	return func(l *Logic) {
+		l.audit = &auditLog{sink: sink}
+	}
+}
+
+func auditHash(entry *AuditEntry) (string, error) {
+	unhashed := *entry
+	unhashed.Hash = ""
+
+	data, err := marshal(&unhashed)
+	if err != nil {
+		return "", err
+	}
+	return hashResult(entry.PrevHash + string(data)), nil
+}
+
+type auditLog struct {
+	mu     sync.Mutex
+	sink   AuditSink
+	loaded bool
+	next   uint64
+	prev   string
+}
+
+func (a *auditLog) write(entry *AuditEntry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.loaded {
+		last, err := a.sink.LastAuditEntry()
+		if err != nil {
+			return err
+		}
+		if last != nil {
+			a.next = last.Sequence + 1
+			a.prev = last.Hash
+		}
+		a.loaded = true
+	}
+
+	entry.Sequence = a.next
+	entry.PrevHash = a.prev
+
+	hash, err := auditHash(entry)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	if err := a.sink.WriteAuditEntry(entry); err != nil {
+		return err
+	}
+	a.next++
+	a.prev = hash
+	return nil
+}
+
+// auditCall records the call into the audit log, if there is one. A result
+// is only handed out once it has been recorded, and it is saved into the
+// round store only after that, so every stored round has an audit entry.
+func (l *Logic) auditCall(call string, source string, input interface{}, output interface{}, callErr error, startedAt time.Time) error {
+	if l.audit == nil {
+		return nil
+	}
+
+	entry := &AuditEntry{
+		Call:       call,
+		Source:     source,
+		StartedAt:  startedAt.UTC(),
+		FinishedAt: time.Now().UTC(),
+	}
+
+	if input != nil {
+		data, err := marshal(input)
+		if err != nil {
+			return err
+		}
+		entry.Input = data
+	}
+
+	if callErr != nil {
+		entry.Error = callErr.Error()
+	} else {
+		data, err := marshal(output)
+		if err != nil {
+			return err
+		}
+		entry.Output = data
+	}
+
+	if err := l.audit.write(entry); err != nil {
+		return fmt.Errorf("audit log error: %v", err)
+	}
+	return nil
+}
+
+// WriterAuditSink writes entries as JSON lines. It does not know previous
+// entries, so a chain written to it starts over on every restart.
+type WriterAuditSink struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func NewWriterAuditSink(writer io.Writer) *WriterAuditSink {
+	return &WriterAuditSink{writer: writer}
+}
+
+func (s *WriterAuditSink) WriteAuditEntry(entry *AuditEntry) error {
+	data, err := marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.writer.Write(append(data, '\n'))
+	return err
+}
+
+func (s *WriterAuditSink) LastAuditEntry() (*AuditEntry, error) {
+	return nil, nil
+}
+
+// FileAuditSink appends entries as JSON lines to a file, synced to disk on
+// every write, and continues the chain of the entries already in it. Like
+// FileRoundStore it cuts off a last line torn by a crash.
+type FileAuditSink struct {
+	mu   sync.Mutex
+	log  *appendLog
+	last *AuditEntry
+}
+
+func NewFileAuditSink(path string) (*FileAuditSink, error) {
+	var last []byte
+	log, err := openAppendLog(path, func(offset int64, line []byte) error {
+		last = line
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &FileAuditSink{log: log}
+	if last != nil {
+		sink.last = &AuditEntry{}
+		if err := unmarshal(last, sink.last); err != nil {
+			_ = log.Close()
+			return nil, fmt.Errorf("wrong audit entry: %v", err)
+		}
+	}
+	return sink, nil
+}
+
+func (s *FileAuditSink) WriteAuditEntry(entry *AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.log.append(entry); err != nil {
+		return err
+	}
+
+	last := *entry
+	s.last = &last
+	return nil
+}
+
+func (s *FileAuditSink) LastAuditEntry() (*AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.last == nil {
+		return nil, nil
+	}
+	last := *s.last
+	return &last, nil
+}
+
+func (s *FileAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.log.Close()
+}
+
+// VerifyAuditLog walks the chain of JSON lines entries and returns the count
+// of entries. The chain has to start with sequence 0, and removing entries
+// from its end can only be detected against a published last hash.
+func (l *Logic) VerifyAuditLog(reader io.Reader) (uint64, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, 1<<20)
+
+	var count uint64
+	prev := ""
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		entry := &AuditEntry{}
+		if err := unmarshal(scanner.Bytes(), entry); err != nil {
+			return count, fmt.Errorf("wrong audit entry %d: %v", count, err)
+		}
+
+		if entry.Sequence != count {
+			return count, fmt.Errorf("wrong audit entry %d: sequence %d", count, entry.Sequence)
+		}
+		if entry.PrevHash != prev {
+			return count, fmt.Errorf("wrong audit entry %d: previous hash", count)
+		}
+
+		hash, err := auditHash(entry)
+		if err != nil {
+			return count, err
+		}
+		if hash != entry.Hash {
+			return count, fmt.Errorf("wrong audit entry %d: hash", count)
+		}
+
+		prev = entry.Hash
+		count++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return count, err
+	}
+	if count == 0 {
+		return 0, errors.New("empty audit log")
+	}
+	return count, nil
+}
@@ -0,0 +1,144 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLogic_VerifyAuditLog(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	instance := New(os.Getenv("API_KEY"), WithEntropy(NewHashStream("audit")), WithAuditSink(NewWriterAuditSink(buffer)))
+
+	if _, err := instance.GenerateDiceNumber(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.GenerateMinesAllocation(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := instance.GenerateCrashCoefficient(ctx); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	count, err := instance.VerifyAuditLog(bytes.NewReader(buffer.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3, but got %d", count)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if !strings.Contains(lines[0], `"call":"GenerateDiceNumber"`) || !strings.Contains(lines[0], `"game":"dice"`) {
+		t.Fatalf("expected dice entry, but got %s", lines[0])
+	}
+	if !strings.Contains(lines[2], `"source":"random.org"`) || !strings.Contains(lines[2], `"error":"random.org api error`) {
+		t.Fatalf("expected failed crash entry, but got %s", lines[2])
+	}
+
+	removed := lines[0] + "\n" + lines[2] + "\n"
+	if _, err := instance.VerifyAuditLog(strings.NewReader(removed)); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	edited := strings.Replace(buffer.String(), `"call":"GenerateMinesAllocation"`, `"call":"GenerateDiceNumber"`, 1)
+	if _, err := instance.VerifyAuditLog(strings.NewReader(edited)); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	if _, err := instance.VerifyAuditLog(strings.NewReader(lines[1] + "\n")); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestFileAuditSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	for i := 0; i < 2; i++ {
+		sink, err := NewFileAuditSink(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		instance := New(os.Getenv("API_KEY"), WithAuditSink(sink))
+		if _, err := instance.GenerateDiceNumber(); err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	count, err := New(os.Getenv("API_KEY")).VerifyAuditLog(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2, but got %d", count)
+	}
+}
+
+func TestFileAuditSinkTornLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	sink, err := NewFileAuditSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance := New(os.Getenv("API_KEY"), WithAuditSink(sink))
+	if _, err := instance.GenerateDiceNumber(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.WriteString(`{"sequence":1,"call":"GenerateDi`); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	sink, err = NewFileAuditSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance = New(os.Getenv("API_KEY"), WithAuditSink(sink))
+	if _, err := instance.GenerateDiceNumber(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err = os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	count, err := New(os.Getenv("API_KEY")).VerifyAuditLog(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2, but got %d", count)
+	}
+}
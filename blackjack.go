@@ -0,0 +1,380 @@
+package logic
+
+import (
+	"errors"
+	"strings"
+)
+
+type BlackjackRules struct {
+	Decks            uint8
+	DealerHitsSoft17 bool
+	Resplit          bool
+	Surrender        bool
+}
+
+type BlackjackAction uint8
+
+const (
+	BlackjackHit BlackjackAction = iota
+	BlackjackStand
+	BlackjackDouble
+	BlackjackSplit
+	BlackjackSurrender
+	BlackjackInsurance
+	BlackjackNoInsurance
+)
+
+type BlackjackPhase uint8
+
+const (
+	BlackjackInsurancePhase BlackjackPhase = iota
+	BlackjackPlayerPhase
+	BlackjackFinishedPhase
+)
+
+const BlackjackMaxHands = 4
+
+// BlackjackHand holds its bet and payout as multiples of the initial stake.
+type BlackjackHand struct {
+	Cards   []Card
+	Bet     float64
+	Payout  float64
+	Done    bool
+	Doubled bool
+	Split   bool
+}
+
+// BlackjackGame deals cards from Deck in order starting at Position: player,
+// dealer, player, dealer hole card, then every drawn card as it is needed.
+// Payout is the total return including insurance as a multiple of the stake.
+type BlackjackGame struct {
+	Rules       BlackjackRules
+	Deck        *CardDeck
+	Position    int
+	Hands       []*BlackjackHand
+	Active      int
+	Dealer      []Card
+	Phase       BlackjackPhase
+	Insurance   bool
+	Surrendered bool
+	Payout      float64
+}
+
+func blackjackValue(cards []Card) (int, bool) {
+	total := 0
+	aces := 0
+	for _, card := range cards {
+		rank := card.Rank()
+		switch {
+		case rank == CardAce:
+			aces++
+			total++
+		case rank >= 10:
+			total += 10
+		default:
+			total += int(rank)
+		}
+	}
+
+	if aces > 0 && total+10 <= 21 {
+		return total + 10, true
+	}
+	return total, false
+}
+
+func blackjackNatural(cards []Card) bool {
+	value, _ := blackjackValue(cards)
+	return len(cards) == 2 && value == 21
+}
+
+func (g *BlackjackGame) draw() (Card, error) {
+	if g.Position >= len(g.Deck.Cards) {
+		return 0, errors.New("deck is empty")
+	}
+
+	card := g.Deck.Cards[g.Position]
+	g.Position++
+	return card, nil
+}
+
+func (g *BlackjackGame) dealTo(hand *BlackjackHand) error {
+	card, err := g.draw()
+	if err != nil {
+		return err
+	}
+
+	hand.Cards = append(hand.Cards, card)
+	if value, _ := blackjackValue(hand.Cards); value >= 21 {
+		hand.Done = true
+	}
+	return nil
+}
+
+func (l *Logic) NewBlackjackGame(deck *CardDeck, rules BlackjackRules) (*BlackjackGame, error) {
+	if rules.Decks != deck.Decks {
+		return nil, errors.New("wrong deck")
+	}
+
+	game := &BlackjackGame{
+		Rules: rules,
+		Deck:  deck,
+		Hands: []*BlackjackHand{{Bet: 1}},
+	}
+
+	hand := game.Hands[0]
+	for i := 0; i < 2; i++ {
+		card, err := game.draw()
+		if err != nil {
+			return nil, err
+		}
+		hand.Cards = append(hand.Cards, card)
+
+		card, err = game.draw()
+		if err != nil {
+			return nil, err
+		}
+		game.Dealer = append(game.Dealer, card)
+	}
+
+	if game.Dealer[0].Rank() == CardAce {
+		game.Phase = BlackjackInsurancePhase
+		return game, nil
+	}
+
+	l.blackjackPeek(game)
+	return game, nil
+}
+
+// blackjackPeek ends the game right after the deal when the dealer or the
+// player has a natural.
+func (l *Logic) blackjackPeek(game *BlackjackGame) {
+	game.Phase = BlackjackPlayerPhase
+
+	if blackjackNatural(game.Dealer) || blackjackNatural(game.Hands[0].Cards) {
+		game.Hands[0].Done = true
+		l.blackjackSettle(game)
+	}
+}
+
+func (l *Logic) BlackjackAct(game *BlackjackGame, action BlackjackAction) error {
+	switch game.Phase {
+	case BlackjackInsurancePhase:
+		switch action {
+		case BlackjackInsurance:
+			game.Insurance = true
+		case BlackjackNoInsurance:
+		default:
+			return errors.New("wrong action")
+		}
+		l.blackjackPeek(game)
+		return nil
+	case BlackjackFinishedPhase:
+		return errors.New("game is finished")
+	}
+
+	hand := game.Hands[game.Active]
+	switch action {
+	case BlackjackHit:
+		if err := game.dealTo(hand); err != nil {
+			return err
+		}
+	case BlackjackStand:
+		hand.Done = true
+	case BlackjackDouble:
+		if len(hand.Cards) != 2 {
+			return errors.New("wrong action")
+		}
+		if err := game.dealTo(hand); err != nil {
+			return err
+		}
+		hand.Bet *= 2
+		hand.Doubled = true
+		hand.Done = true
+	case BlackjackSplit:
+		if err := l.blackjackSplit(game, hand); err != nil {
+			return err
+		}
+	case BlackjackSurrender:
+		if !game.Rules.Surrender || len(game.Hands) != 1 || len(hand.Cards) != 2 {
+			return errors.New("wrong action")
+		}
+		hand.Done = true
+		game.Surrendered = true
+	default:
+		return errors.New("wrong action")
+	}
+
+	return l.blackjackNext(game)
+}
+
+func (l *Logic) blackjackSplit(game *BlackjackGame, hand *BlackjackHand) error {
+	if len(hand.Cards) != 2 || len(game.Hands) >= BlackjackMaxHands {
+		return errors.New("wrong action")
+	}
+	if hand.Split && !game.Rules.Resplit {
+		return errors.New("wrong action")
+	}
+
+	if hand.Cards[0].Rank() != hand.Cards[1].Rank() {
+		return errors.New("wrong action")
+	}
+
+	aces := hand.Cards[0].Rank() == CardAce
+
+	// Both hands are dealt to right away when splitting aces, so check the
+	// shoe before changing anything.
+	cards := 1
+	if aces {
+		cards = 2
+	}
+	if len(game.Deck.Cards)-game.Position < cards {
+		return errors.New("deck is empty")
+	}
+	split := &BlackjackHand{
+		Cards: []Card{hand.Cards[1]},
+		Bet:   hand.Bet,
+		Split: true,
+	}
+	hand.Cards = hand.Cards[:1]
+	hand.Split = true
+
+	hands := append([]*BlackjackHand(nil), game.Hands[:game.Active+1]...)
+	hands = append(hands, split)
+	game.Hands = append(hands, game.Hands[game.Active+1:]...)
+
+	if err := game.dealTo(hand); err != nil {
+		return err
+	}
+	if aces {
+		if err := game.dealTo(split); err != nil {
+			return err
+		}
+		hand.Done = true
+		split.Done = true
+	}
+	return nil
+}
+
+// blackjackNext moves to the next unfinished hand, dealing the second card to
+// a split hand when it becomes active, and lets the dealer play after the
+// last one.
+func (l *Logic) blackjackNext(game *BlackjackGame) error {
+	for game.Active < len(game.Hands) {
+		hand := game.Hands[game.Active]
+		if len(hand.Cards) == 1 {
+			if err := game.dealTo(hand); err != nil {
+				return err
+			}
+		}
+		if !hand.Done {
+			return nil
+		}
+		game.Active++
+	}
+	game.Active = len(game.Hands) - 1
+
+	live := false
+	for _, hand := range game.Hands {
+		if value, _ := blackjackValue(hand.Cards); value <= 21 && !game.Surrendered {
+			live = true
+		}
+	}
+
+	for live {
+		value, soft := blackjackValue(game.Dealer)
+		if value > 17 || (value == 17 && (!soft || !game.Rules.DealerHitsSoft17)) {
+			break
+		}
+
+		card, err := game.draw()
+		if err != nil {
+			return err
+		}
+		game.Dealer = append(game.Dealer, card)
+	}
+
+	l.blackjackSettle(game)
+	return nil
+}
+
+func (l *Logic) blackjackSettle(game *BlackjackGame) {
+	game.Phase = BlackjackFinishedPhase
+	game.Payout = 0
+
+	dealer, _ := blackjackValue(game.Dealer)
+	dealerNatural := blackjackNatural(game.Dealer)
+
+	if game.Insurance && dealerNatural {
+		game.Payout += 1.5
+	}
+
+	for _, hand := range game.Hands {
+		value, _ := blackjackValue(hand.Cards)
+		natural := len(game.Hands) == 1 && blackjackNatural(hand.Cards)
+
+		switch {
+		case game.Surrendered:
+			hand.Payout = hand.Bet / 2
+		case natural && dealerNatural:
+			hand.Payout = hand.Bet
+		case natural:
+			hand.Payout = hand.Bet * 2.5
+		case dealerNatural, value > 21:
+			hand.Payout = 0
+		case dealer > 21 || value > dealer:
+			hand.Payout = hand.Bet * 2
+		case value == dealer:
+			hand.Payout = hand.Bet
+		default:
+			hand.Payout = 0
+		}
+
+		game.Payout += hand.Payout
+	}
+}
+
+// BlackjackStake returns the total amount wagered as a multiple of the
+// initial stake, including doubles, splits and insurance.
+func (l *Logic) BlackjackStake(game *BlackjackGame) float64 {
+	var stake float64
+	for _, hand := range game.Hands {
+		stake += hand.Bet
+	}
+	if game.Insurance {
+		stake += 0.5
+	}
+	return stake
+}
+
+// VerifyBlackjackGame replays the actions on the revealed shoe, which has to
+// bring the game to its end.
+func (l *Logic) VerifyBlackjackGame(result string, resultHash string, rules BlackjackRules, actions []BlackjackAction) (*BlackjackGame, error) {
+	deck, err := l.CardDeckFromString(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if deck.ResultHash != strings.ToLower(resultHash) {
+		return nil, errors.New("wrong result hash")
+	}
+
+	return l.playBlackjackGame(deck, rules, actions)
+}
+
+func (l *Logic) playBlackjackGame(deck *CardDeck, rules BlackjackRules, actions []BlackjackAction) (*BlackjackGame, error) {
+	game, err := l.NewBlackjackGame(deck, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, action := range actions {
+		if err := l.BlackjackAct(game, action); err != nil {
+			return nil, err
+		}
+	}
+
+	if game.Phase != BlackjackFinishedPhase {
+		return nil, errors.New("wrong action sequence")
+	}
+	return game, nil
+}
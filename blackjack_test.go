@@ -0,0 +1,146 @@
+package logic
+
+import (
+	"os"
+	"testing"
+)
+
+func blackjackTestDeck(prefix ...Card) string {
+	used := make(map[Card]bool)
+	for _, card := range prefix {
+		used[card] = true
+	}
+	cards := append([]Card(nil), prefix...)
+	for c := Card(0); c < DeckLength; c++ {
+		if !used[c] {
+			cards = append(cards, c)
+		}
+	}
+	return "left|" + joinCards(cards, "|") + "|right"
+}
+
+func blackjackTestGame(t *testing.T, rules BlackjackRules, actions []BlackjackAction, prefix ...Card) *BlackjackGame {
+	instance := New(os.Getenv("API_KEY"))
+	result := blackjackTestDeck(prefix...)
+	game, err := instance.VerifyBlackjackGame(result, hashResult(result), rules, actions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return game
+}
+
+func TestLogic_BlackjackStand(t *testing.T) {
+	rules := BlackjackRules{Decks: 1}
+	game := blackjackTestGame(t, rules, []BlackjackAction{BlackjackStand}, 9, 21, 6, 20)
+	if game.Payout != 1 {
+		t.Fatalf("expected push, but got %v", game.Payout)
+	}
+}
+
+func TestLogic_BlackjackNatural(t *testing.T) {
+	rules := BlackjackRules{Decks: 1}
+	game := blackjackTestGame(t, rules, nil, 0, 21, 9, 20)
+	if game.Payout != 2.5 {
+		t.Fatalf("expected 2.5, but got %v", game.Payout)
+	}
+}
+
+func TestLogic_BlackjackInsurance(t *testing.T) {
+	rules := BlackjackRules{Decks: 1}
+	game := blackjackTestGame(t, rules, []BlackjackAction{BlackjackInsurance}, 9, 13, 8, 22)
+	instance := New(os.Getenv("API_KEY"))
+	if game.Payout != 1.5 || instance.BlackjackStake(game) != 1.5 {
+		t.Fatalf("expected 1.5 for 1.5, but got %v for %v", game.Payout, instance.BlackjackStake(game))
+	}
+}
+
+func TestLogic_BlackjackSplit(t *testing.T) {
+	rules := BlackjackRules{Decks: 1}
+	actions := []BlackjackAction{BlackjackSplit, BlackjackDouble, BlackjackStand}
+	game := blackjackTestGame(t, rules, actions, 7, 35, 20, 32, 1, 48, 2)
+	if len(game.Hands) != 2 || game.Hands[0].Payout != 4 || game.Hands[1].Payout != 0 {
+		t.Fatalf("expected payouts 4 and 0, but got %v", game.Hands)
+	}
+	instance := New(os.Getenv("API_KEY"))
+	if game.Payout != 4 || instance.BlackjackStake(game) != 3 {
+		t.Fatalf("expected 4 for 3, but got %v for %v", game.Payout, instance.BlackjackStake(game))
+	}
+}
+
+func TestLogic_BlackjackSurrender(t *testing.T) {
+	rules := BlackjackRules{Decks: 1, Surrender: true}
+	game := blackjackTestGame(t, rules, []BlackjackAction{BlackjackSurrender}, 9, 21, 5, 20)
+	if game.Payout != 0.5 {
+		t.Fatalf("expected 0.5, but got %v", game.Payout)
+	}
+
+	instance := New(os.Getenv("API_KEY"))
+	result := blackjackTestDeck(9, 21, 5, 20)
+	_, err := instance.VerifyBlackjackGame(result, hashResult(result), BlackjackRules{Decks: 1}, []BlackjackAction{BlackjackSurrender})
+	if err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_BlackjackDealerHitsSoft17(t *testing.T) {
+	game := blackjackTestGame(t, BlackjackRules{Decks: 1}, []BlackjackAction{BlackjackStand}, 9, 5, 8, 13, 1)
+	if game.Payout != 2 {
+		t.Fatalf("expected 2, but got %v", game.Payout)
+	}
+
+	game = blackjackTestGame(t, BlackjackRules{Decks: 1, DealerHitsSoft17: true}, []BlackjackAction{BlackjackStand}, 9, 5, 8, 13, 1)
+	if game.Payout != 1 || len(game.Dealer) != 3 {
+		t.Fatalf("expected push after a dealer hit, but got %v with %v", game.Payout, game.Dealer)
+	}
+}
+
+func TestLogic_VerifyBlackjackGameWrongInput(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	result := blackjackTestDeck(9, 21, 6, 20)
+	rules := BlackjackRules{Decks: 1}
+
+	if _, err := instance.VerifyBlackjackGame(result, "wrong", rules, []BlackjackAction{BlackjackStand}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyBlackjackGame(result, hashResult(result), rules, nil); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyBlackjackGame(result, hashResult(result), rules, []BlackjackAction{BlackjackSplit}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyBlackjackGame(result, hashResult(result), BlackjackRules{Decks: 2}, []BlackjackAction{BlackjackStand}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_BlackjackSplitRanks(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	result := blackjackTestDeck(9, 21, 12, 20)
+	_, err := instance.VerifyBlackjackGame(result, hashResult(result), BlackjackRules{Decks: 1}, []BlackjackAction{BlackjackSplit})
+	if err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_BlackjackEmptyDeck(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	for _, action := range []BlackjackAction{BlackjackDouble, BlackjackSplit} {
+		deck, err := instance.CardDeckFromString(blackjackTestDeck(7, 21, 20, 22))
+		if err != nil {
+			t.Fatal(err)
+		}
+		game, err := instance.NewBlackjackGame(deck, BlackjackRules{Decks: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.Position = len(deck.Cards)
+
+		if err := instance.BlackjackAct(game, action); err == nil {
+			t.Fatalf("expected error but got nil")
+		}
+		hand := game.Hands[0]
+		if len(game.Hands) != 1 || len(hand.Cards) != 2 || hand.Bet != 1 || hand.Doubled || hand.Split {
+			t.Fatalf("expected unchanged hand, but got %v", game.Hands)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Card is a card of a standard 52-card deck numbered from 0 to 51 as
+// suit*13 + rank-1, so 0 is the ace of clubs and 51 is the king of spades.
+type Card uint8
+
+const (
+	CardClubs uint8 = iota
+	CardDiamonds
+	CardHearts
+	CardSpades
+)
+
+const (
+	CardAce   uint8 = 1
+	CardJack  uint8 = 11
+	CardQueen uint8 = 12
+	CardKing  uint8 = 13
+)
+
+const (
+	DeckLength  = 52
+	MaxShoeDeck = 8
+)
+
+var cardRanks = []string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+var cardSuits = []string{"C", "D", "H", "S"}
+
+type CardDeck struct {
+	Cards      []Card
+	Decks      uint8
+	LeftSeed   string
+	RightSeed  string
+	Result     string
+	ResultHash string
+}
+
+func (c Card) Rank() uint8 {
+	return uint8(c)%13 + 1
+}
+
+func (c Card) Suit() uint8 {
+	return uint8(c) / 13
+}
+
+func (c Card) String() string {
+	if c >= DeckLength {
+		return "?"
+	}
+	return cardRanks[c.Rank()-1] + cardSuits[c.Suit()]
+}
+
+func joinCards(cards []Card, sep string) string {
+	var builder strings.Builder
+
+	for i, c := range cards {
+		builder.WriteString(strconv.FormatUint(uint64(c), 10))
+		if i+1 < len(cards) {
+			builder.WriteString(sep)
+		}
+	}
+
+	return builder.String()
+}
+
+func (l *Logic) GenerateCardDeck(ctx context.Context, decks uint8) (*CardDeck, error) {
+	startedAt := time.Now()
+	deck, err := l.generateCardDeck(decks)
+	l.observeGeneration("cards", deck, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSeeded(ctx, deck.ResultHash, deck); err != nil {
+		return nil, err
+	}
+	return deck, nil
+}
+
+func (l *Logic) generateCardDeck(decks uint8) (*CardDeck, error) {
+	if decks < 1 || decks > MaxShoeDeck {
+		return nil, errors.New("wrong decks count")
+	}
+
+	base := make([]uint8, DeckLength*int(decks))
+	for i := range base {
+		base[i] = uint8(i % DeckLength)
+	}
+	shuffled, err := l.allocate(base, len(base))
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]Card, len(shuffled))
+	for i, card := range shuffled {
+		cards[i] = Card(card)
+	}
+
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	join := joinCards(cards, "|")
+
+	result := fmt.Sprintf("%s|%s|%s", leftSeed, join, rightSeed)
+
+	deck := &CardDeck{
+		Cards:      cards,
+		Decks:      decks,
+		LeftSeed:   leftSeed,
+		RightSeed:  rightSeed,
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return deck, nil
+}
+
+func (l *Logic) CardDeckFromString(result string) (*CardDeck, error) {
+	elems := strings.Split(result, "|")
+	if len(elems) < 2 || (len(elems)-2)%DeckLength != 0 {
+		return nil, errors.New("wrong result string")
+	}
+
+	decks := (len(elems) - 2) / DeckLength
+	if decks < 1 || decks > MaxShoeDeck {
+		return nil, errors.New("wrong result string")
+	}
+
+	cards := make([]Card, len(elems)-2)
+	counts := make([]int, DeckLength)
+	for i := range cards {
+		card, err := strconv.ParseUint(elems[i+1], 10, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		if card >= DeckLength {
+			return nil, errors.New("wrong result string")
+		}
+
+		counts[card]++
+		cards[i] = Card(card)
+	}
+
+	for _, count := range counts {
+		if count != decks {
+			return nil, errors.New("wrong result string")
+		}
+	}
+
+	deck := &CardDeck{
+		Cards:      cards,
+		Decks:      uint8(decks),
+		LeftSeed:   elems[0],
+		RightSeed:  elems[len(elems)-1],
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return deck, nil
+}
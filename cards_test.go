@@ -0,0 +1,77 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestCard_String(t *testing.T) {
+	cases := map[Card]string{0: "AC", 9: "10C", 25: "KD", 39: "AS", 51: "KS", 52: "?"}
+	for card, expected := range cases {
+		if card.String() != expected {
+			t.Fatalf("expected \"%s\", but got \"%s\"", expected, card.String())
+		}
+	}
+}
+
+func TestLogic_GenerateCardDeck(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	deck, err := instance.GenerateCardDeck(context.Background(), 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deck.Cards) != 6*DeckLength {
+		t.Fatalf("expected %d cards, but got %d", 6*DeckLength, len(deck.Cards))
+	}
+
+	restored, err := instance.CardDeckFromString(deck.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Decks != 6 || restored.ResultHash != deck.ResultHash {
+		t.Fatalf("expected 6 decks with \"%s\", but got %d with \"%s\"", deck.ResultHash, restored.Decks, restored.ResultHash)
+	}
+	for i, card := range deck.Cards {
+		if restored.Cards[i] != card {
+			t.Fatalf("expected %s at %d, but got %s", card, i, restored.Cards[i])
+		}
+	}
+}
+
+func TestLogic_GenerateCardDeckReproducible(t *testing.T) {
+	a, err := New(os.Getenv("API_KEY"), WithEntropy(NewHashStream("deck"))).GenerateCardDeck(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := New(os.Getenv("API_KEY"), WithEntropy(NewHashStream("deck"))).GenerateCardDeck(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Result != b.Result {
+		t.Fatalf("expected \"%s\", but got \"%s\"", a.Result, b.Result)
+	}
+}
+
+func TestLogic_CardDeckWrongInput(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	if _, err := instance.GenerateCardDeck(context.Background(), 0); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.GenerateCardDeck(context.Background(), MaxShoeDeck+1); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	deck, err := instance.GenerateCardDeck(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deck.Cards[0] = deck.Cards[1]
+	broken := deck.LeftSeed + "|" + joinCards(deck.Cards, "|") + "|" + deck.RightSeed
+	if _, err := instance.CardDeckFromString(broken); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.CardDeckFromString("a|1|b"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
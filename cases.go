@@ -0,0 +1,168 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type CaseItem struct {
+	Name   string
+	Weight uint64
+	Value  float64
+}
+
+type Case struct {
+	Name  string
+	Price float64
+	Items []CaseItem
+}
+
+type CaseReport struct {
+	TotalWeight   uint64
+	Chances       []float64
+	ExpectedValue float64
+	RTP           float64
+}
+
+// CaseOpening holds the roll from 0 to the total weight of the case minus 1.
+// Items own consecutive ranges of rolls in the order they are listed, each as
+// long as its weight.
+type CaseOpening struct {
+	Game       string
+	Roll       uint64
+	Item       int
+	LeftSeed   string
+	RightSeed  string
+	Result     string
+	ResultHash string
+}
+
+func caseWeight(c *Case) (uint64, error) {
+	if c.Name == "" || len(c.Items) == 0 || c.Price <= 0 || math.IsNaN(c.Price) || math.IsInf(c.Price, 0) {
+		return 0, errors.New("wrong case")
+	}
+
+	var total uint64
+	for _, item := range c.Items {
+		if item.Weight == 0 || item.Value < 0 || math.IsNaN(item.Value) || math.IsInf(item.Value, 0) || total+item.Weight < total {
+			return 0, errors.New("wrong case item")
+		}
+		total += item.Weight
+	}
+	return total, nil
+}
+
+func caseItem(c *Case, roll uint64) int {
+	var start uint64
+	for i, item := range c.Items {
+		if roll < start+item.Weight {
+			return i
+		}
+		start += item.Weight
+	}
+	return -1
+}
+
+func (l *Logic) ValidateCase(c *Case) error {
+	_, err := caseWeight(c)
+	return err
+}
+
+func (l *Logic) CaseReport(c *Case) (*CaseReport, error) {
+	total, err := caseWeight(c)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CaseReport{
+		TotalWeight: total,
+		Chances:     make([]float64, len(c.Items)),
+	}
+	for i, item := range c.Items {
+		report.Chances[i] = float64(item.Weight) / float64(total)
+		report.ExpectedValue += item.Value * report.Chances[i]
+	}
+	report.RTP = report.ExpectedValue / c.Price
+	return report, nil
+}
+
+func (l *Logic) GenerateCaseOpening(ctx context.Context, c *Case) (*CaseOpening, error) {
+	startedAt := time.Now()
+	opening, err := l.generateCaseOpening(c)
+	l.observeGeneration("case", opening, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSeeded(ctx, opening.ResultHash, opening); err != nil {
+		return nil, err
+	}
+	return opening, nil
+}
+
+func (l *Logic) generateCaseOpening(c *Case) (*CaseOpening, error) {
+	total, err := caseWeight(c)
+	if err != nil {
+		return nil, err
+	}
+
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	roll, err := UniformInt(l.entropy, total)
+	if err != nil {
+		return nil, err
+	}
+
+	result := fmt.Sprintf("%s|%d|%s", leftSeed, roll, rightSeed)
+
+	opening := &CaseOpening{
+		Game:       c.Name,
+		Roll:       roll,
+		Item:       caseItem(c, roll),
+		LeftSeed:   leftSeed,
+		RightSeed:  rightSeed,
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return opening, nil
+}
+
+func (l *Logic) CaseOpeningFromString(c *Case, result string) (*CaseOpening, error) {
+	total, err := caseWeight(c)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := strings.Split(result, "|")
+	if len(elems) != 3 {
+		return nil, errors.New("wrong result string")
+	}
+
+	roll, err := strconv.ParseUint(elems[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if roll >= total {
+		return nil, errors.New("wrong result string")
+	}
+
+	opening := &CaseOpening{
+		Game:       c.Name,
+		Roll:       roll,
+		Item:       caseItem(c, roll),
+		LeftSeed:   elems[0],
+		RightSeed:  elems[2],
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return opening, nil
+}
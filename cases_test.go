@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"context"
+	"math"
+	"os"
+	"testing"
+)
+
+func caseTestCase() *Case {
+	return &Case{
+		Name:  "test",
+		Price: 10,
+		Items: []CaseItem{
+			{Name: "common", Weight: 80, Value: 5},
+			{Name: "rare", Weight: 15, Value: 20},
+			{Name: "legendary", Weight: 5, Value: 70},
+		},
+	}
+}
+
+func TestLogic_CaseReport(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	report, err := instance.CaseReport(caseTestCase())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.TotalWeight != 100 || !float64Compare(report.Chances, []float64{0.8, 0.15, 0.05}) {
+		t.Fatalf("unexpected report %v", report)
+	}
+	if report.ExpectedValue != 10.5 || report.RTP != 1.05 {
+		t.Fatalf("expected 10.5 and 1.05, but got %v and %v", report.ExpectedValue, report.RTP)
+	}
+}
+
+func TestLogic_CaseOpeningFromString(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	c := caseTestCase()
+
+	cases := map[string]int{"a|0|b": 0, "a|79|b": 0, "a|80|b": 1, "a|94|b": 1, "a|95|b": 2, "a|99|b": 2}
+	for result, item := range cases {
+		opening, err := instance.CaseOpeningFromString(c, result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if opening.Item != item {
+			t.Fatalf("expected %d for %s, but got %d", item, result, opening.Item)
+		}
+	}
+
+	if _, err := instance.CaseOpeningFromString(c, "a|100|b"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_GenerateCaseOpening(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	c := caseTestCase()
+	opening, err := instance.GenerateCaseOpening(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := instance.CaseOpeningFromString(c, opening.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Item != opening.Item || restored.ResultHash != opening.ResultHash {
+		t.Fatalf("expected %d, but got %d", opening.Item, restored.Item)
+	}
+}
+
+func TestLogic_ValidateCase(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	c := caseTestCase()
+	c.Items[1].Weight = 0
+	if err := instance.ValidateCase(c); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if err := instance.ValidateCase(&Case{Price: 1}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		c := caseTestCase()
+		c.Price = value
+		if err := instance.ValidateCase(c); err == nil {
+			t.Fatalf("price %v: expected error but got nil", value)
+		}
+
+		c = caseTestCase()
+		c.Items[0].Value = value
+		if err := instance.ValidateCase(c); err == nil {
+			t.Fatalf("value %v: expected error but got nil", value)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/CoinCup/logic"
+)
+
+const usage = `usage: verify <game> [flags]
+
+commands:
+  audit   -file <audit log>
+  <game>  -reveal <result or random object> -commitment <result hash or signature> [-bet <bet json>] [-key <random.org key or certificate pem>]
+
+games:
+`
+
+// usageError is an error in the arguments rather than a failed verification.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+func printUsage(stderr io.Writer, instance *logic.Logic) {
+	fmt.Fprint(stderr, usage)
+	for _, name := range instance.Games().Names() {
+		fmt.Fprintf(stderr, "  %s\n", name)
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run verifies according to args and returns the exit code: 0 on success,
+// 1 when the verification fails and 2 on wrong arguments.
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	instance := logic.New("")
+
+	if len(args) < 1 {
+		printUsage(stderr, instance)
+		return 2
+	}
+
+	var err error
+	if args[0] == "audit" {
+		err = verifyAudit(instance, args[1:], stdout, stderr)
+	} else {
+		if _, gameErr := instance.Games().Game(args[0]); gameErr != nil {
+			printUsage(stderr, instance)
+			return 2
+		}
+		err = verifyGame(args[0], args[1:], stdout, stderr)
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprintf(stderr, "%v\n", err)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "verification failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func verifyAudit(instance *logic.Logic, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("audit", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	path := flags.String("file", "", "audit log file")
+	if err := flags.Parse(args); err != nil {
+		return &usageError{err}
+	}
+
+	if *path == "" {
+		return &usageError{errors.New("-file is required")}
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	count, err := instance.VerifyAuditLog(file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "chain:   ok\n")
+	fmt.Fprintf(stdout, "entries: %d\n", count)
+	return nil
+}
+
+// readSignatureKey reads the public key of random.org from a PEM file with
+// either the public key or the certificate.
+func readSignatureKey(path string) (*rsa.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("wrong key file")
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = certificate.PublicKey
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("wrong key file")
+	}
+	return rsaKey, nil
+}
+
+func verifyGame(name string, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	reveal := flags.String("reveal", "", "revealed result or random object")
+	commitment := flags.String("commitment", "", "published result hash or signature")
+	betJSON := flags.String("bet", "", "bet to settle as JSON")
+	keyPath := flags.String("key", "", "PEM file with the public key or certificate of random.org to check signatures locally")
+	if err := flags.Parse(args); err != nil {
+		return &usageError{err}
+	}
+
+	if *reveal == "" || *commitment == "" {
+		return &usageError{errors.New("-reveal and -commitment are required")}
+	}
+
+	var options []logic.Option
+	if *keyPath != "" {
+		key, err := readSignatureKey(*keyPath)
+		if err != nil {
+			return &usageError{fmt.Errorf("-key: %v", err)}
+		}
+		options = append(options, logic.WithSignatureKey(key))
+	}
+
+	instance := logic.New("", options...)
+	game, err := instance.Games().Game(name)
+	if err != nil {
+		return &usageError{err}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := game.Verify(ctx, *reveal, *commitment)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "commitment:  ok\n")
+	fmt.Fprintf(stdout, "result:      %s\n", data)
+	if *betJSON == "" {
+		return nil
+	}
+
+	bet, err := instance.DecodeBet(game.Name(), []byte(*betJSON))
+	if err != nil {
+		return err
+	}
+
+	coefficient, err := game.Settle(result, bet)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "coefficient: %.2f\n", coefficient)
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CoinCup/logic"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	number, err := logic.New("").GenerateDiceNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	wrongKeyPath := filepath.Join(dir, "wrong.pem")
+	if err := ioutil.WriteFile(wrongKeyPath, []byte("not a key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	random := `{"method":"generateSignedDecimalFractions","n":1,"decimalPlaces":3,"data":[0.5],"serialNumber":42}`
+	digest := sha512.Sum512([]byte(random))
+	signed, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := base64.StdEncoding.EncodeToString(signed)
+
+	auditPath := filepath.Join(dir, "audit.jsonl")
+	sink, err := logic.NewFileAuditSink(auditPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := logic.New("", logic.WithAuditSink(sink)).GenerateDiceNumber(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+	}{
+		{"no arguments", nil, 2, ""},
+		{"unknown game", []string{"poker"}, 2, ""},
+		{"unknown flag", []string{"dice", "-seed", "1"}, 2, ""},
+		{"no commitment", []string{"dice", "-reveal", number.Result}, 2, ""},
+		{"dice", []string{"dice", "-reveal", number.Result, "-commitment", number.ResultHash}, 0, "commitment:  ok"},
+		{"dice bet", []string{"dice", "-reveal", number.Result, "-commitment", number.ResultHash, "-bet", `{"type":0,"chance":4950}`}, 0, "coefficient:"},
+		{"dice wrong bet", []string{"dice", "-reveal", number.Result, "-commitment", number.ResultHash, "-bet", `{"type":0,"chance":0}`}, 1, "commitment:  ok"},
+		{"dice wrong commitment", []string{"dice", "-reveal", number.Result, "-commitment", strings.Repeat("0", 128)}, 1, ""},
+		{"crash key", []string{"crash", "-reveal", random, "-commitment", signature, "-key", keyPath, "-bet", `{"target":1.5}`}, 0, "coefficient: 1.50"},
+		{"crash wrong signature", []string{"crash", "-reveal", random + " ", "-commitment", signature, "-key", keyPath}, 1, ""},
+		{"crash wrong key", []string{"crash", "-reveal", random, "-commitment", signature, "-key", wrongKeyPath}, 2, ""},
+		{"crash no key file", []string{"crash", "-reveal", random, "-commitment", signature, "-key", filepath.Join(dir, "none.pem")}, 2, ""},
+		{"audit", []string{"audit", "-file", auditPath}, 0, "entries: 1"},
+		{"audit no file", []string{"audit"}, 2, ""},
+		{"audit missing file", []string{"audit", "-file", filepath.Join(dir, "none.jsonl")}, 1, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(test.args, &stdout, &stderr); code != test.code {
+				t.Fatalf("expected %d, but got %d: %s", test.code, code, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), test.stdout) {
+				t.Fatalf("expected %q in %q", test.stdout, stdout.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CoinflipHeads uint8 = 0
+	CoinflipTails uint8 = 1
+)
+
+const CoinflipMaxStreak uint8 = 20
+
+type CoinflipFlips struct {
+	Sides      []uint8
+	LeftSeed   string
+	RightSeed  string
+	Result     string
+	ResultHash string
+}
+
+type CoinflipStreak struct {
+	Flips       *CoinflipFlips
+	Streak      uint8
+	Won         bool
+	Coefficient float64
+}
+
+func (l *Logic) GenerateCoinflipCoefficients() []float64 {
+	chances := make([]float64, CoinflipMaxStreak)
+	for i := range chances {
+		chances[i] = 0.5
+	}
+	return l.survivalCoefficients(chances)
+}
+
+func (l *Logic) GenerateCoinflipFlips(ctx context.Context) (*CoinflipFlips, error) {
+	startedAt := time.Now()
+	flips, err := l.generateCoinflipFlips()
+	l.observeGeneration("coinflip", flips, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSeeded(ctx, flips.ResultHash, flips); err != nil {
+		return nil, err
+	}
+	return flips, nil
+}
+
+func (l *Logic) generateCoinflipFlips() (*CoinflipFlips, error) {
+	sides := make([]uint8, CoinflipMaxStreak)
+	for i := range sides {
+		side, err := UniformInt(l.entropy, 2)
+		if err != nil {
+			return nil, err
+		}
+		sides[i] = uint8(side)
+	}
+
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	join := joinUint8(sides, "|")
+
+	result := fmt.Sprintf("%s|%s|%s", leftSeed, join, rightSeed)
+
+	flips := &CoinflipFlips{
+		Sides:      sides,
+		LeftSeed:   leftSeed,
+		RightSeed:  rightSeed,
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return flips, nil
+}
+
+func (l *Logic) CoinflipFlipsFromString(result string) (*CoinflipFlips, error) {
+	elems := strings.Split(result, "|")
+	if len(elems) != int(CoinflipMaxStreak)+2 {
+		return nil, errors.New("wrong result string")
+	}
+
+	sides := make([]uint8, CoinflipMaxStreak)
+	for i := range sides {
+		side, err := strconv.ParseUint(elems[i+1], 10, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		if side > 1 {
+			return nil, errors.New("wrong result string")
+		}
+
+		sides[i] = uint8(side)
+	}
+
+	flips := &CoinflipFlips{
+		Sides:      sides,
+		LeftSeed:   elems[0],
+		RightSeed:  elems[CoinflipMaxStreak+1],
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return flips, nil
+}
+
+func (l *Logic) VerifyCoinflipStreak(result string, resultHash string, guesses []uint8) (*CoinflipStreak, error) {
+	flips, err := l.CoinflipFlipsFromString(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if flips.ResultHash != strings.ToLower(resultHash) {
+		return nil, errors.New("wrong result hash")
+	}
+
+	return l.playCoinflipStreak(flips, guesses)
+}
+
+func (l *Logic) playCoinflipStreak(flips *CoinflipFlips, guesses []uint8) (*CoinflipStreak, error) {
+	if len(guesses) == 0 || len(guesses) > int(CoinflipMaxStreak) {
+		return nil, errors.New("wrong guess sequence")
+	}
+
+	streak := &CoinflipStreak{
+		Flips: flips,
+	}
+
+	for i, guess := range guesses {
+		if guess > 1 {
+			return nil, errors.New("wrong guess sequence")
+		}
+
+		if flips.Sides[i] != guess {
+			if i+1 < len(guesses) {
+				return nil, errors.New("wrong guess sequence")
+			}
+			return streak, nil
+		}
+
+		streak.Streak++
+	}
+
+	streak.Won = true
+	streak.Coefficient = l.GenerateCoinflipCoefficients()[streak.Streak-1]
+	return streak, nil
+}
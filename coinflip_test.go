@@ -0,0 +1,65 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestLogic_GenerateCoinflipCoefficients(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	coefficients := instance.GenerateCoinflipCoefficients()
+	if len(coefficients) != int(CoinflipMaxStreak) {
+		t.Fatalf("expected %d coefficients, but got %d", CoinflipMaxStreak, len(coefficients))
+	}
+	if !float64Compare(coefficients[:4], []float64{1.9, 3.8, 7.6, 15.2}) {
+		t.Fatalf("expected [1.9 3.8 7.6 15.2], but got %v", coefficients[:4])
+	}
+}
+
+func TestLogic_CoinflipFlipsFromString(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	flips, err := instance.GenerateCoinflipFlips(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := instance.CoinflipFlipsFromString(flips.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !uint8Compare(flips.Sides, restored.Sides) || flips.ResultHash != restored.ResultHash {
+		t.Fatalf("expected \"%v\", but got \"%v\"", flips.Sides, restored.Sides)
+	}
+}
+
+func TestLogic_VerifyCoinflipStreak(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	flips, err := instance.CoinflipFlipsFromString("a|0|1|1|0|0|0|0|0|0|0|0|0|0|0|0|0|0|0|0|0|b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	streak, err := instance.VerifyCoinflipStreak(flips.Result, flips.ResultHash, []uint8{0, 1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !streak.Won || streak.Streak != 3 || streak.Coefficient != 7.6 {
+		t.Fatalf("expected win of 3 with 7.6, but got %v of %d with %v", streak.Won, streak.Streak, streak.Coefficient)
+	}
+
+	streak, err = instance.VerifyCoinflipStreak(flips.Result, flips.ResultHash, []uint8{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if streak.Won || streak.Streak != 1 || streak.Coefficient != 0 {
+		t.Fatalf("expected loss after 1, but got %v after %d", streak.Won, streak.Streak)
+	}
+
+	if _, err := instance.VerifyCoinflipStreak(flips.Result, flips.ResultHash, []uint8{1, 1}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyCoinflipStreak(flips.Result, "wrong", []uint8{0}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
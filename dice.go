@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"errors"
+	"math"
+)
+
+type DiceBetType uint8
+
+const (
+	DiceRollUnder DiceBetType = iota
+	DiceRollOver
+	DiceInside
+	DiceOutside
+)
+
+// Dice chances are measured in hundredths of a percent, 1 is 0.01%.
+const (
+	DiceMinChance uint16 = 1
+	DiceMaxChance uint16 = 9000
+)
+
+const diceChanceScale = 10000
+const diceChanceStep = DiceLength / diceChanceScale
+
+// DiceBet wins when the dice value falls into [Low, High), or outside of it
+// for DiceOutside.
+type DiceBet struct {
+	Type        DiceBetType
+	Chance      uint16
+	Low         uint64
+	High        uint64
+	Coefficient float64
+}
+
+// diceCoefficient is rounded down to hundredths, as in Plinko and Keno, so
+// that the return to player never exceeds 1 - edge.
+func (l *Logic) diceCoefficient(chance uint16) float64 {
+	coefficient := (1 - l.edge) * diceChanceScale / float64(chance)
+	return math.Floor(coefficient*100+1e-9) / 100
+}
+
+func (l *Logic) newDiceBet(betType DiceBetType, chance uint16, low uint64, high uint64) (*DiceBet, error) {
+	if chance < DiceMinChance || chance > DiceMaxChance {
+		return nil, errors.New("wrong chance")
+	}
+
+	bet := &DiceBet{
+		Type:        betType,
+		Chance:      chance,
+		Low:         low,
+		High:        high,
+		Coefficient: l.diceCoefficient(chance),
+	}
+	return bet, nil
+}
+
+func (l *Logic) DiceRollUnderBet(chance uint16) (*DiceBet, error) {
+	target := uint64(chance) * diceChanceStep
+	return l.newDiceBet(DiceRollUnder, chance, 0, target)
+}
+
+func (l *Logic) DiceRollOverBet(chance uint16) (*DiceBet, error) {
+	target := DiceLength - uint64(chance)*diceChanceStep
+	return l.newDiceBet(DiceRollOver, chance, target, DiceLength)
+}
+
+func (l *Logic) DiceInsideBet(low uint64, high uint64) (*DiceBet, error) {
+	if low >= high || high > DiceLength || (high-low)%diceChanceStep != 0 {
+		return nil, errors.New("wrong range")
+	}
+
+	chance := (high - low) / diceChanceStep
+	return l.newDiceBet(DiceInside, uint16(chance), low, high)
+}
+
+func (l *Logic) DiceOutsideBet(low uint64, high uint64) (*DiceBet, error) {
+	if low >= high || high > DiceLength || (high-low)%diceChanceStep != 0 {
+		return nil, errors.New("wrong range")
+	}
+
+	chance := (DiceLength - (high - low)) / diceChanceStep
+	return l.newDiceBet(DiceOutside, uint16(chance), low, high)
+}
+
+func (l *Logic) SettleDiceBet(bet *DiceBet, number *DiceNumber) (float64, error) {
+	if number.Value >= DiceLength {
+		return 0, errors.New("wrong dice number")
+	}
+
+	inside := number.Value >= bet.Low && number.Value < bet.High
+	if inside == (bet.Type == DiceOutside) {
+		return 0, nil
+	}
+
+	return bet.Coefficient, nil
+}
@@ -0,0 +1,117 @@
+package logic
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+func TestLogic_DiceRollUnderBet(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	bet, err := instance.DiceRollUnderBet(4950)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bet.High != 495000 || bet.Coefficient != 1.91 {
+		t.Fatalf("expected 495000 with 1.91, but got %d with %v", bet.High, bet.Coefficient)
+	}
+
+	for value, expected := range map[uint64]float64{0: 1.91, 494999: 1.91, 495000: 0} {
+		coefficient, err := instance.SettleDiceBet(bet, &DiceNumber{Value: value})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if coefficient != expected {
+			t.Fatalf("expected %v for %d, but got %v", expected, value, coefficient)
+		}
+	}
+}
+
+func TestLogic_DiceRollOverBet(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	bet, err := instance.DiceRollOverBet(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bet.Low != 999900 || bet.Coefficient != 9500 {
+		t.Fatalf("expected 999900 with 9500, but got %d with %v", bet.Low, bet.Coefficient)
+	}
+
+	for value, expected := range map[uint64]float64{999899: 0, 999900: 9500, 999999: 9500} {
+		coefficient, err := instance.SettleDiceBet(bet, &DiceNumber{Value: value})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if coefficient != expected {
+			t.Fatalf("expected %v for %d, but got %v", expected, value, coefficient)
+		}
+	}
+}
+
+func TestLogic_DiceCoefficientRTP(t *testing.T) {
+	for _, edge := range []float64{0, 0.01, DefaultHouseEdge, 0.1} {
+		instance := New(os.Getenv("API_KEY"), WithHouseEdge(edge))
+		for chance := DiceMinChance; chance <= DiceMaxChance; chance++ {
+			bet, err := instance.DiceRollUnderBet(chance)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rtp := bet.Coefficient * float64(chance) / diceChanceScale; rtp > 1-edge+1e-9 {
+				t.Fatalf("expected rtp up to %v for chance %d, but got %v", 1-edge, chance, rtp)
+			}
+		}
+	}
+}
+
+func TestLogic_DiceRangeBets(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"), WithHouseEdge(0.01))
+	inside, err := instance.DiceInsideBet(250000, 750000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside, err := instance.DiceOutsideBet(250000, 750000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inside.Chance != 5000 || outside.Chance != 5000 || inside.Coefficient != 1.98 {
+		t.Fatalf("expected 5000 with 1.98, but got %d, %d with %v", inside.Chance, outside.Chance, inside.Coefficient)
+	}
+
+	for _, value := range []uint64{0, 249999, 250000, 749999, 750000, 999999} {
+		number := &DiceNumber{Value: value}
+		a, _ := instance.SettleDiceBet(inside, number)
+		b, _ := instance.SettleDiceBet(outside, number)
+		if (a == 0) == (b == 0) {
+			t.Fatalf("expected exactly one winning bet for %d", value)
+		}
+	}
+}
+
+func TestLogic_DiceBetWrongInput(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	if _, err := instance.DiceRollUnderBet(0); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.DiceRollOverBet(9001); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.DiceInsideBet(100, 150); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.DiceOutsideBet(0, 50000); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_DiceWrongHouseEdge(t *testing.T) {
+	for _, edge := range []float64{-0.01, 1, 2, math.NaN()} {
+		instance := New(os.Getenv("API_KEY"), WithHouseEdge(edge))
+		bet, err := instance.DiceRollUnderBet(5000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bet.Coefficient != 1.9 {
+			t.Fatalf("expected 1.9 for edge %v, but got %v", edge, bet.Coefficient)
+		}
+	}
+}
@@ -0,0 +1,527 @@
+package logic
+
+import (
+	"errors"
+)
+
+// ResultVersion is the schema version written into every encoded result.
+// Decoders accept versions from 1 up to ResultVersion.
+const ResultVersion = 1
+
+// resultHeader opens every encoded result, e.g.
+// {"game":"dice","version":1,"value":914655,...}.
+type resultHeader struct {
+	Game    string `json:"game"`
+	Version int    `json:"version"`
+}
+
+func (h *resultHeader) check(game string) error {
+	if h.Game != game {
+		return errors.New("wrong game")
+	}
+	if h.Version < 1 || h.Version > ResultVersion {
+		return errors.New("unsupported version")
+	}
+	return nil
+}
+
+// jsonUint8s encodes as an array of numbers instead of a base64 string.
+type jsonUint8s []uint8
+
+func (s jsonUint8s) MarshalJSON() ([]byte, error) {
+	ints := make([]int, len(s))
+	for i, v := range s {
+		ints[i] = int(v)
+	}
+	return marshal(ints)
+}
+
+func (s *jsonUint8s) UnmarshalJSON(data []byte) error {
+	var ints []int
+	if err := unmarshal(data, &ints); err != nil {
+		return err
+	}
+
+	*s = make(jsonUint8s, len(ints))
+	for i, v := range ints {
+		if v < 0 || v > 255 {
+			return errors.New("wrong uint8 value")
+		}
+		(*s)[i] = uint8(v)
+	}
+	return nil
+}
+
+type signedJSON struct {
+	resultHeader
+	Random       string `json:"random"`
+	Signature    string `json:"signature"`
+	SerialNumber uint64 `json:"serialNumber"`
+}
+
+type seededJSON struct {
+	resultHeader
+	LeftSeed   string `json:"leftSeed"`
+	RightSeed  string `json:"rightSeed"`
+	Result     string `json:"result"`
+	ResultHash string `json:"resultHash"`
+}
+
+func newSigned(game string, random string, signature string, serialNumber uint64) signedJSON {
+	return signedJSON{
+		resultHeader: resultHeader{Game: game, Version: ResultVersion},
+		Random:       random,
+		Signature:    signature,
+		SerialNumber: serialNumber,
+	}
+}
+
+func newSeeded(game string, leftSeed string, rightSeed string, result string, resultHash string) seededJSON {
+	return seededJSON{
+		resultHeader: resultHeader{Game: game, Version: ResultVersion},
+		LeftSeed:     leftSeed,
+		RightSeed:    rightSeed,
+		Result:       result,
+		ResultHash:   resultHash,
+	}
+}
+
+type crashJSON struct {
+	signedJSON
+	Value float64 `json:"value"`
+}
+
+func (c CrashCoefficient) MarshalJSON() ([]byte, error) {
+	return marshal(&crashJSON{
+		signedJSON: newSigned("crash", c.Random, c.Signature, c.SerialNumber),
+		Value:      c.Value,
+	})
+}
+
+func (c *CrashCoefficient) UnmarshalJSON(data []byte) error {
+	v := &crashJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("crash"); err != nil {
+		return err
+	}
+
+	*c = CrashCoefficient{Value: v.Value, Random: v.Random, Signature: v.Signature, SerialNumber: v.SerialNumber}
+	return nil
+}
+
+type intSignedJSON struct {
+	signedJSON
+	Value int `json:"value"`
+}
+
+func (n DoubleNumber) MarshalJSON() ([]byte, error) {
+	return marshal(&intSignedJSON{
+		signedJSON: newSigned("double", n.Random, n.Signature, n.SerialNumber),
+		Value:      n.Value,
+	})
+}
+
+func (n *DoubleNumber) UnmarshalJSON(data []byte) error {
+	v := &intSignedJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("double"); err != nil {
+		return err
+	}
+
+	*n = DoubleNumber{Value: v.Value, Random: v.Random, Signature: v.Signature, SerialNumber: v.SerialNumber}
+	return nil
+}
+
+func (n RouletteNumber) MarshalJSON() ([]byte, error) {
+	return marshal(&intSignedJSON{
+		signedJSON: newSigned("roulette", n.Random, n.Signature, n.SerialNumber),
+		Value:      n.Value,
+	})
+}
+
+func (n *RouletteNumber) UnmarshalJSON(data []byte) error {
+	v := &intSignedJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("roulette"); err != nil {
+		return err
+	}
+
+	*n = RouletteNumber{Value: v.Value, Random: v.Random, Signature: v.Signature, SerialNumber: v.SerialNumber}
+	return nil
+}
+
+type minesJSON struct {
+	seededJSON
+	Places jsonUint8s `json:"places"`
+}
+
+func (a MinesAllocation) MarshalJSON() ([]byte, error) {
+	return marshal(&minesJSON{
+		seededJSON: newSeeded("mines", a.LeftSeed, a.RightSeed, a.Result, a.ResultHash),
+		Places:     a.Places,
+	})
+}
+
+func (a *MinesAllocation) UnmarshalJSON(data []byte) error {
+	v := &minesJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("mines"); err != nil {
+		return err
+	}
+
+	*a = MinesAllocation{Places: v.Places, LeftSeed: v.LeftSeed, RightSeed: v.RightSeed, Result: v.Result, ResultHash: v.ResultHash}
+	return nil
+}
+
+type diceJSON struct {
+	seededJSON
+	Value uint64 `json:"value"`
+}
+
+func (n DiceNumber) MarshalJSON() ([]byte, error) {
+	return marshal(&diceJSON{
+		seededJSON: newSeeded("dice", n.LeftSeed, n.RightSeed, n.Result, n.ResultHash),
+		Value:      n.Value,
+	})
+}
+
+func (n *DiceNumber) UnmarshalJSON(data []byte) error {
+	v := &diceJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("dice"); err != nil {
+		return err
+	}
+
+	*n = DiceNumber{Value: v.Value, LeftSeed: v.LeftSeed, RightSeed: v.RightSeed, Result: v.Result, ResultHash: v.ResultHash}
+	return nil
+}
+
+type limboJSON struct {
+	seededJSON
+	Value       uint64  `json:"value"`
+	Coefficient float64 `json:"coefficient"`
+}
+
+func (r LimboResult) MarshalJSON() ([]byte, error) {
+	return marshal(&limboJSON{
+		seededJSON:  newSeeded("limbo", r.LeftSeed, r.RightSeed, r.Result, r.ResultHash),
+		Value:       r.Value,
+		Coefficient: r.Coefficient,
+	})
+}
+
+func (r *LimboResult) UnmarshalJSON(data []byte) error {
+	v := &limboJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("limbo"); err != nil {
+		return err
+	}
+
+	*r = LimboResult{
+		Value:       v.Value,
+		Coefficient: v.Coefficient,
+		LeftSeed:    v.LeftSeed,
+		RightSeed:   v.RightSeed,
+		Result:      v.Result,
+		ResultHash:  v.ResultHash,
+	}
+	return nil
+}
+
+type plinkoJSON struct {
+	seededJSON
+	Directions jsonUint8s `json:"directions"`
+}
+
+func (p PlinkoPath) MarshalJSON() ([]byte, error) {
+	return marshal(&plinkoJSON{
+		seededJSON: newSeeded("plinko", p.LeftSeed, p.RightSeed, p.Result, p.ResultHash),
+		Directions: p.Directions,
+	})
+}
+
+func (p *PlinkoPath) UnmarshalJSON(data []byte) error {
+	v := &plinkoJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("plinko"); err != nil {
+		return err
+	}
+
+	*p = PlinkoPath{Directions: v.Directions, LeftSeed: v.LeftSeed, RightSeed: v.RightSeed, Result: v.Result, ResultHash: v.ResultHash}
+	return nil
+}
+
+type kenoJSON struct {
+	seededJSON
+	Numbers jsonUint8s `json:"numbers"`
+}
+
+func (d KenoDraw) MarshalJSON() ([]byte, error) {
+	return marshal(&kenoJSON{
+		seededJSON: newSeeded("keno", d.LeftSeed, d.RightSeed, d.Result, d.ResultHash),
+		Numbers:    d.Numbers,
+	})
+}
+
+func (d *KenoDraw) UnmarshalJSON(data []byte) error {
+	v := &kenoJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("keno"); err != nil {
+		return err
+	}
+
+	*d = KenoDraw{Numbers: v.Numbers, LeftSeed: v.LeftSeed, RightSeed: v.RightSeed, Result: v.Result, ResultHash: v.ResultHash}
+	return nil
+}
+
+type coinflipJSON struct {
+	seededJSON
+	Sides jsonUint8s `json:"sides"`
+}
+
+func (f CoinflipFlips) MarshalJSON() ([]byte, error) {
+	return marshal(&coinflipJSON{
+		seededJSON: newSeeded("coinflip", f.LeftSeed, f.RightSeed, f.Result, f.ResultHash),
+		Sides:      f.Sides,
+	})
+}
+
+func (f *CoinflipFlips) UnmarshalJSON(data []byte) error {
+	v := &coinflipJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("coinflip"); err != nil {
+		return err
+	}
+
+	*f = CoinflipFlips{Sides: v.Sides, LeftSeed: v.LeftSeed, RightSeed: v.RightSeed, Result: v.Result, ResultHash: v.ResultHash}
+	return nil
+}
+
+type cardsJSON struct {
+	seededJSON
+	Cards jsonUint8s `json:"cards"`
+	Decks uint8      `json:"decks"`
+}
+
+func (d CardDeck) MarshalJSON() ([]byte, error) {
+	cards := make(jsonUint8s, len(d.Cards))
+	for i, card := range d.Cards {
+		cards[i] = uint8(card)
+	}
+
+	return marshal(&cardsJSON{
+		seededJSON: newSeeded(cardsGameName(d.Decks), d.LeftSeed, d.RightSeed, d.Result, d.ResultHash),
+		Cards:      cards,
+		Decks:      d.Decks,
+	})
+}
+
+func (d *CardDeck) UnmarshalJSON(data []byte) error {
+	v := &cardsJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if v.Decks < 1 || v.Decks > MaxShoeDeck {
+		return errors.New("wrong decks count")
+	}
+	if err := v.check(cardsGameName(v.Decks)); err != nil {
+		return err
+	}
+
+	cards := make([]Card, len(v.Cards))
+	for i, card := range v.Cards {
+		if card >= DeckLength {
+			return errors.New("wrong card")
+		}
+		cards[i] = Card(card)
+	}
+
+	*d = CardDeck{Cards: cards, Decks: v.Decks, LeftSeed: v.LeftSeed, RightSeed: v.RightSeed, Result: v.Result, ResultHash: v.ResultHash}
+	return nil
+}
+
+type towerJSON struct {
+	seededJSON
+	Difficulty TowerDifficulty `json:"difficulty"`
+	Rows       []jsonUint8s    `json:"rows"`
+}
+
+func (a TowerAllocation) MarshalJSON() ([]byte, error) {
+	rows := make([]jsonUint8s, len(a.Rows))
+	for i, row := range a.Rows {
+		rows[i] = row
+	}
+
+	return marshal(&towerJSON{
+		seededJSON: newSeeded(towerGameNames[a.Difficulty], a.LeftSeed, a.RightSeed, a.Result, a.ResultHash),
+		Difficulty: a.Difficulty,
+		Rows:       rows,
+	})
+}
+
+func (a *TowerAllocation) UnmarshalJSON(data []byte) error {
+	v := &towerJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	name, ok := towerGameNames[v.Difficulty]
+	if !ok {
+		return errors.New("wrong difficulty")
+	}
+	if err := v.check(name); err != nil {
+		return err
+	}
+
+	rows := make([][]uint8, len(v.Rows))
+	for i, row := range v.Rows {
+		rows[i] = row
+	}
+
+	*a = TowerAllocation{Difficulty: v.Difficulty, Rows: rows, LeftSeed: v.LeftSeed, RightSeed: v.RightSeed, Result: v.Result, ResultHash: v.ResultHash}
+	return nil
+}
+
+type slotsJSON struct {
+	seededJSON
+	Stops []uint16 `json:"stops"`
+}
+
+func (s SlotSpin) MarshalJSON() ([]byte, error) {
+	return marshal(&slotsJSON{
+		seededJSON: newSeeded(s.Game, s.LeftSeed, s.RightSeed, s.Result, s.ResultHash),
+		Stops:      s.Stops,
+	})
+}
+
+func (s *SlotSpin) UnmarshalJSON(data []byte) error {
+	v := &slotsJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if v.Game == "" {
+		return errors.New("wrong game")
+	}
+	if err := v.check(v.Game); err != nil {
+		return err
+	}
+
+	*s = SlotSpin{Game: v.Game, Stops: v.Stops, LeftSeed: v.LeftSeed, RightSeed: v.RightSeed, Result: v.Result, ResultHash: v.ResultHash}
+	return nil
+}
+
+type caseJSON struct {
+	seededJSON
+	Roll uint64 `json:"roll"`
+	Item int    `json:"item"`
+}
+
+func (o CaseOpening) MarshalJSON() ([]byte, error) {
+	return marshal(&caseJSON{
+		seededJSON: newSeeded(o.Game, o.LeftSeed, o.RightSeed, o.Result, o.ResultHash),
+		Roll:       o.Roll,
+		Item:       o.Item,
+	})
+}
+
+func (o *CaseOpening) UnmarshalJSON(data []byte) error {
+	v := &caseJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if v.Game == "" {
+		return errors.New("wrong game")
+	}
+	if err := v.check(v.Game); err != nil {
+		return err
+	}
+
+	*o = CaseOpening{Game: v.Game, Roll: v.Roll, Item: v.Item, LeftSeed: v.LeftSeed, RightSeed: v.RightSeed, Result: v.Result, ResultHash: v.ResultHash}
+	return nil
+}
+
+type jackpotJSON struct {
+	signedJSON
+	Entries []JackpotEntry `json:"entries"`
+	Tickets uint64         `json:"tickets"`
+	Value   float64        `json:"value"`
+	Ticket  uint64         `json:"ticket"`
+	Winner  string         `json:"winner"`
+}
+
+func (d JackpotDraw) MarshalJSON() ([]byte, error) {
+	return marshal(&jackpotJSON{
+		signedJSON: newSigned("jackpot", d.Random, d.Signature, d.SerialNumber),
+		Entries:    d.Entries,
+		Tickets:    d.Tickets,
+		Value:      d.Value,
+		Ticket:     d.Ticket,
+		Winner:     d.Winner,
+	})
+}
+
+func (d *JackpotDraw) UnmarshalJSON(data []byte) error {
+	v := &jackpotJSON{}
+	if err := unmarshal(data, v); err != nil {
+		return err
+	}
+	if err := v.check("jackpot"); err != nil {
+		return err
+	}
+
+	*d = JackpotDraw{
+		Entries:      v.Entries,
+		Tickets:      v.Tickets,
+		Value:        v.Value,
+		Ticket:       v.Ticket,
+		Winner:       v.Winner,
+		Random:       v.Random,
+		Signature:    v.Signature,
+		SerialNumber: v.SerialNumber,
+	}
+	return nil
+}
+
+// DecodeResult decodes any encoded result into its concrete type chosen by
+// the game field, the name of the game in the registry, e.g. *DiceNumber for
+// "dice" or *TowerAllocation for "tower-easy". Results of slot machines and
+// cases decode only once their game is registered.
+func (l *Logic) DecodeResult(data []byte) (interface{}, error) {
+	header := &resultHeader{}
+	if err := unmarshal(data, header); err != nil {
+		return nil, err
+	}
+
+	game, err := l.games.Game(header.Game)
+	if err != nil {
+		return nil, errors.New("wrong game")
+	}
+
+	g, ok := game.(resultGame)
+	if !ok {
+		return nil, errors.New("wrong game")
+	}
+
+	result := g.newResult()
+	if err := unmarshal(data, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
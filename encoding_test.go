@@ -0,0 +1,163 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLogic_DecodeResult(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"), WithEntropy(NewHashStream("encoding")))
+
+	mines, err := instance.GenerateMinesAllocation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dice, err := instance.GenerateDiceNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	limbo, err := instance.GenerateLimboResult(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plinko, err := instance.GeneratePlinkoPath(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	keno, err := instance.GenerateKenoDraw(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinflip, err := instance.GenerateCoinflipFlips(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	deck, err := instance.GenerateCardDeck(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tower, err := instance.GenerateTowerAllocation(context.Background(), TowerHard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slots, err := instance.SlotsGame(slotTestConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := instance.CaseGame(caseTestCase())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, game := range []Game{slots, c} {
+		if err := instance.Games().Register(game); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := []interface{}{
+		&CrashCoefficient{Value: 1.93, Random: `{"data":[0.5]}`, Signature: "signature", SerialNumber: 1},
+		&DoubleNumber{Value: 7, Random: `{"data":[7]}`, Signature: "signature", SerialNumber: 2},
+		&RouletteNumber{Value: 36, Random: `{"data":[36]}`, Signature: "signature", SerialNumber: 3},
+		&JackpotDraw{
+			Entries:      []JackpotEntry{{Player: "a", Stake: 10}, {Player: "b", Stake: 30}},
+			Tickets:      40,
+			Value:        0.5,
+			Ticket:       20,
+			Winner:       "b",
+			Random:       `{"data":[0.5]}`,
+			Signature:    "signature",
+			SerialNumber: 6,
+		},
+		mines, dice, limbo, plinko, keno, coinflip, deck, tower,
+		&SlotSpin{Game: "slots", Stops: []uint16{1, 300, 2}, LeftSeed: "a", RightSeed: "b", Result: "a|1|300|2|b", ResultHash: hashResult("a|1|300|2|b")},
+		&CaseOpening{Game: "test", Roll: 95, Item: 2, LeftSeed: "a", RightSeed: "b", Result: "a|95|b", ResultHash: hashResult("a|95|b")},
+	}
+
+	for _, result := range results {
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := instance.DecodeResult(data)
+		if err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+
+		header := &resultHeader{}
+		if err := json.Unmarshal(data, header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := instance.Games().Game(header.Game); err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+
+		if !reflect.DeepEqual(decoded, result) {
+			t.Fatalf("expected \"%+v\", but got \"%+v\"", result, decoded)
+		}
+	}
+}
+
+func TestMinesAllocation_MarshalJSON(t *testing.T) {
+	mines := &MinesAllocation{Places: []uint8{6, 19}, LeftSeed: "a", RightSeed: "b", Result: "a|6|19|b", ResultHash: "hash"}
+
+	data, err := json.Marshal(mines)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"game":"mines","version":1,"leftSeed":"a","rightSeed":"b","result":"a|6|19|b","resultHash":"hash","places":[6,19]}`
+	if string(data) != expected {
+		t.Fatalf("expected %s, but got %s", expected, data)
+	}
+}
+
+func TestLogic_DecodeResultWrong(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+
+	for _, data := range []string{
+		`{"game":"poker","version":1}`,
+		`{"game":"dice","version":2,"value":1}`,
+		`{"game":"dice","version":0,"value":1}`,
+		`{"game":"mines","version":1,"places":[256]}`,
+		`{"game":"cards-1","version":1,"decks":1,"cards":[52]}`,
+		`{"game":"cards-1","version":1,"decks":2,"cards":[1]}`,
+		`{"game":"cards","version":1,"decks":1,"cards":[1]}`,
+		`{"game":"tower-easy","version":1,"difficulty":1,"rows":[]}`,
+		`{"game":"tower","version":1,"difficulty":0,"rows":[]}`,
+		`{"game":"slots","version":1,"stops":[1]}`,
+		`{`,
+	} {
+		if _, err := instance.DecodeResult([]byte(data)); err == nil {
+			t.Fatalf("%s: expected error but got nil", data)
+		}
+	}
+
+	number := &DiceNumber{}
+	if err := json.Unmarshal([]byte(`{"game":"limbo","version":1,"value":1}`), number); err == nil || !strings.Contains(err.Error(), "wrong game") {
+		t.Fatalf("expected wrong game error, but got %v", err)
+	}
+}
+
+func TestDecimal_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(&Decimal{Value: 0.5, Random: "r", Signature: "s", SerialNumber: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"value":0.5,"random":"r","signature":"s","serial_number":42}`; string(data) != expected {
+		t.Fatalf("expected %s, but got %s", expected, data)
+	}
+
+	data, err = json.Marshal(&Integer{Value: 7, Random: "r", Signature: "s", SerialNumber: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"value":7,"random":"r","signature":"s","serial_number":42}`; string(data) != expected {
+		t.Fatalf("expected %s, but got %s", expected, data)
+	}
+}
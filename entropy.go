@@ -0,0 +1,77 @@
+package logic
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// UniformInt returns an integer uniformly distributed in [0, n) using bytes
+// read from r.
+//
+// The procedure is fixed so that anyone holding the same bytes gets the same
+// result: let k be the bit length of n-1; read ceil(k/8) bytes, interpret them
+// as a big-endian integer, keep its lowest k bits and accept the value if it is
+// less than n, otherwise discard it and repeat with the next bytes. For n == 1
+// no bytes are read.
+func UniformInt(r io.Reader, n uint64) (uint64, error) {
+	if n == 0 {
+		return 0, errors.New("wrong range")
+	}
+
+	length := bits.Len64(n - 1)
+	if length == 0 {
+		return 0, nil
+	}
+
+	mask := ^uint64(0) >> uint(64-length)
+
+	buffer := make([]byte, 8)
+	size := (length + 7) / 8
+	for {
+		if _, err := io.ReadFull(r, buffer[8-size:]); err != nil {
+			return 0, err
+		}
+
+		value := binary.BigEndian.Uint64(buffer) & mask
+		if value < n {
+			return value, nil
+		}
+	}
+}
+
+type hashStream struct {
+	seed    []byte
+	counter uint64
+	block   []byte
+}
+
+// NewHashStream returns a deterministic byte stream made of the blocks
+// SHA512(seed || counter), where counter is a big-endian uint64 starting at 0.
+// It is not safe for concurrent use.
+func NewHashStream(seed string) io.Reader {
+	return &hashStream{seed: []byte(seed)}
+}
+
+func (s *hashStream) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(s.block) == 0 {
+			counter := make([]byte, 8)
+			binary.BigEndian.PutUint64(counter, s.counter)
+			s.counter++
+
+			hash := sha512.New()
+			hash.Write(s.seed)
+			hash.Write(counter)
+			s.block = hash.Sum(nil)
+		}
+
+		copied := copy(p[n:], s.block)
+		s.block = s.block[copied:]
+		n += copied
+	}
+	return n, nil
+}
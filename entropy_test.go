@@ -0,0 +1,87 @@
+package logic
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestUniformInt(t *testing.T) {
+	// 36 needs 6 bits: 0xff -> 63 and 0x64 -> 36 are rejected, 0x45 -> 5 is accepted.
+	value, err := UniformInt(bytes.NewReader([]byte{0xff, 0x64, 0x45}), 36)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 5 {
+		t.Fatalf("expected 5, but got %d", value)
+	}
+
+	// 1000000 needs 20 bits read from 3 big-endian bytes.
+	value, err = UniformInt(bytes.NewReader([]byte{0xff, 0x42, 0x3f}), DiceLength)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 999999 {
+		t.Fatalf("expected 999999, but got %d", value)
+	}
+
+	value, err = UniformInt(bytes.NewReader(nil), 1)
+	if err != nil || value != 0 {
+		t.Fatalf("expected 0 without reading, but got %d, %v", value, err)
+	}
+}
+
+func TestUniformIntWrongInput(t *testing.T) {
+	if _, err := UniformInt(bytes.NewReader([]byte{0}), 0); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := UniformInt(bytes.NewReader([]byte{0xff}), 36); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestUniformIntDistribution(t *testing.T) {
+	stream := NewHashStream("distribution")
+	counts := make([]int, 5)
+	for i := 0; i < 50000; i++ {
+		value, err := UniformInt(stream, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[value]++
+	}
+	for value, count := range counts {
+		if count < 9500 || count > 10500 {
+			t.Fatalf("unexpected count %d for %d: %v", count, value, counts)
+		}
+	}
+}
+
+func TestNewHashStream(t *testing.T) {
+	data := make([]byte, 80)
+	if _, err := io.ReadFull(NewHashStream("seed"), data); err != nil {
+		t.Fatal(err)
+	}
+
+	first := sha512.Sum512([]byte("seed\x00\x00\x00\x00\x00\x00\x00\x00"))
+	second := sha512.Sum512([]byte("seed\x00\x00\x00\x00\x00\x00\x00\x01"))
+	if !bytes.Equal(data[:64], first[:]) || !bytes.Equal(data[64:], second[:16]) {
+		t.Fatalf("unexpected stream %x", data)
+	}
+}
+
+func TestLogic_GenerateDiceNumberReproducible(t *testing.T) {
+	a, err := New(os.Getenv("API_KEY"), WithEntropy(NewHashStream("dice"))).GenerateDiceNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := New(os.Getenv("API_KEY"), WithEntropy(NewHashStream("dice"))).GenerateDiceNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Result != b.Result {
+		t.Fatalf("expected \"%s\", but got \"%s\"", a.Result, b.Result)
+	}
+}
@@ -0,0 +1,1295 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Game is the common set of operations of a game, so that services can handle
+// any registered game by its name.
+//
+// Commitment is what vouches for a result: the result hash published before
+// the game for seeded games, or the random.org signature for signed games.
+// Reveal is what the player checks it against: the result string, or the
+// signed random object. Parse decodes a reveal without checking it, while
+// Verify also checks it against the commitment. Settle returns the
+// coefficient of the bet, 0 when the bet is lost.
+type Game interface {
+	Name() string
+	Generate(ctx context.Context) (interface{}, error)
+	Commitment(result interface{}) (string, error)
+	Reveal(result interface{}) (string, error)
+	Parse(reveal string) (interface{}, error)
+	Verify(ctx context.Context, reveal string, commitment string) (interface{}, error)
+	Settle(result interface{}, bet interface{}) (float64, error)
+}
+
+type GameRegistry struct {
+	mu    sync.RWMutex
+	games map[string]Game
+}
+
+func NewGameRegistry() *GameRegistry {
+	return &GameRegistry{games: make(map[string]Game)}
+}
+
+func (r *GameRegistry) Register(game Game) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := game.Name()
+	if name == "" {
+		return errors.New("wrong game name")
+	}
+	if _, ok := r.games[name]; ok {
+		return errors.New("game already registered")
+	}
+
+	r.games[name] = game
+	return nil
+}
+
+func (r *GameRegistry) Game(name string) (Game, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	game, ok := r.games[name]
+	if !ok {
+		return nil, errors.New("wrong game")
+	}
+	return game, nil
+}
+
+func (r *GameRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.games))
+	for name := range r.games {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Games returns the registry of the instance with every game registered:
+// crash, double, roulette, jackpot, mines, dice, limbo, plinko, keno,
+// coinflip, cards-1 to cards-8 and tower-easy to tower-master. Slot machines and cases
+// depend on their configuration, so they are registered by the caller with
+// SlotsGame and CaseGame.
+func (l *Logic) Games() *GameRegistry {
+	return l.games
+}
+
+func (l *Logic) registerGames() {
+	games := []Game{
+		&crashGame{l}, &doubleGame{l}, &rouletteGame{l}, &jackpotGame{l},
+		&minesGame{l}, &diceGame{l}, &limboGame{l}, &plinkoGame{l}, &kenoGame{l}, &coinflipGame{l},
+	}
+	for decks := uint8(1); decks <= MaxShoeDeck; decks++ {
+		games = append(games, &cardsGame{l, decks})
+	}
+	for difficulty := range towerGameNames {
+		games = append(games, &towerGame{l, difficulty})
+	}
+
+	for _, game := range games {
+		_ = l.games.Register(game)
+	}
+}
+
+// resultGame is implemented by games whose results can be decoded from JSON.
+type resultGame interface {
+	newResult() interface{}
+}
+
+// betGame is implemented by games whose bets can be decoded from JSON.
+type betGame interface {
+	newBet() interface{}
+}
+
+// DecodeBet decodes a JSON bet of the named game into the type its Settle
+// takes, e.g. {"mines":2,"reveals":[3,7]} into *MinesBet for "mines". Slot
+// machines and cases take no bet, so their bets decode to nil.
+func (l *Logic) DecodeBet(name string, data []byte) (interface{}, error) {
+	game, err := l.games.Game(name)
+	if err != nil {
+		return nil, err
+	}
+
+	g, ok := game.(betGame)
+	if !ok {
+		return nil, errWrongBet
+	}
+
+	bet := g.newBet()
+	if bet == nil {
+		return nil, nil
+	}
+	if err := unmarshal(data, bet); err != nil {
+		return nil, errWrongBet
+	}
+	return bet, nil
+}
+
+// verifySeeded parses the reveal of a seeded game and checks that its result
+// hash is the commitment.
+func verifySeeded(game Game, reveal string, commitment string) (interface{}, error) {
+	result, err := game.Parse(reveal)
+	if err != nil {
+		return nil, err
+	}
+
+	resultHash, err := game.Commitment(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if resultHash != strings.ToLower(commitment) {
+		return nil, errors.New("wrong result hash")
+	}
+	return result, nil
+}
+
+var errWrongResult = errors.New("wrong result")
+var errWrongBet = errors.New("wrong bet")
+
+// CrashBet cashes out at Target.
+type CrashBet struct {
+	Target float64
+}
+
+// DoubleBet is placed on the Coefficient color.
+type DoubleBet struct {
+	Coefficient uint8
+}
+
+// MinesBet opens the Reveals cells in order on a field with Mines mines.
+type MinesBet struct {
+	Mines   uint8
+	Reveals []uint8
+}
+
+// LimboBet cashes out at Target.
+type LimboBet struct {
+	Target float64
+}
+
+// PlinkoBet drops the ball through Rows rows of the Risk board.
+type PlinkoBet struct {
+	Rows uint8
+	Risk PlinkoRisk
+}
+
+// KenoBet picks the Picks numbers on the Risk paytable.
+type KenoBet struct {
+	Picks []uint8
+	Risk  KenoRisk
+}
+
+// CoinflipBet guesses the Guesses sides in order.
+type CoinflipBet struct {
+	Guesses []uint8
+}
+
+// CardsBet replays a Blackjack game under Rules with Actions when Blackjack is
+// set, and a HiLo game with Choices otherwise. Rules.Decks must be the shoe
+// size of the game. A Blackjack game is settled with its total return as a
+// multiple of the initial stake.
+type CardsBet struct {
+	Blackjack bool
+	Rules     BlackjackRules
+	Actions   []BlackjackAction
+	Choices   []HiLoChoice
+}
+
+// TowerBet picks the Picks tiles row by row.
+type TowerBet struct {
+	Picks []uint8
+}
+
+// JackpotBet is the entry of Player, who takes the whole pot on a win.
+type JackpotBet struct {
+	Player string
+}
+
+type crashGame struct {
+	l *Logic
+}
+
+func (g *crashGame) Name() string {
+	return "crash"
+}
+
+func (g *crashGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateCrashCoefficient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *crashGame) Commitment(result interface{}) (string, error) {
+	coef, ok := result.(*CrashCoefficient)
+	if !ok {
+		return "", errWrongResult
+	}
+	return coef.Signature, nil
+}
+
+func (g *crashGame) Reveal(result interface{}) (string, error) {
+	coef, ok := result.(*CrashCoefficient)
+	if !ok {
+		return "", errWrongResult
+	}
+	return coef.Random, nil
+}
+
+func (g *crashGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.crashCoefficientFromRandom(reveal, "")
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *crashGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	result, err := g.l.VerifyCrashCoefficient(ctx, reveal, commitment)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *crashGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	coef, ok := result.(*CrashCoefficient)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	crashBet, ok := bet.(*CrashBet)
+	if !ok || crashBet.Target < 1 {
+		return 0, errWrongBet
+	}
+
+	if coef.Value < crashBet.Target {
+		return 0, nil
+	}
+	return crashBet.Target, nil
+}
+
+func (g *crashGame) newBet() interface{} {
+	return &CrashBet{}
+}
+
+func (g *crashGame) newResult() interface{} {
+	return &CrashCoefficient{}
+}
+
+type doubleGame struct {
+	l *Logic
+}
+
+func (g *doubleGame) Name() string {
+	return "double"
+}
+
+func (g *doubleGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateDoubleNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *doubleGame) Commitment(result interface{}) (string, error) {
+	number, ok := result.(*DoubleNumber)
+	if !ok {
+		return "", errWrongResult
+	}
+	return number.Signature, nil
+}
+
+func (g *doubleGame) Reveal(result interface{}) (string, error) {
+	number, ok := result.(*DoubleNumber)
+	if !ok {
+		return "", errWrongResult
+	}
+	return number.Random, nil
+}
+
+func (g *doubleGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.doubleNumberFromRandom(reveal, "")
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *doubleGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	result, err := g.l.VerifyDoubleNumber(ctx, reveal, commitment)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *doubleGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	number, ok := result.(*DoubleNumber)
+	if !ok || number.Value < 0 || number.Value > 53 {
+		return 0, errWrongResult
+	}
+
+	doubleBet, ok := bet.(*DoubleBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+
+	coefficient := g.l.DoubleCoefficientByNumber(uint8(number.Value))
+	if coefficient != doubleBet.Coefficient {
+		return 0, nil
+	}
+	return float64(coefficient), nil
+}
+
+func (g *doubleGame) newBet() interface{} {
+	return &DoubleBet{}
+}
+
+func (g *doubleGame) newResult() interface{} {
+	return &DoubleNumber{}
+}
+
+type minesGame struct {
+	l *Logic
+}
+
+func (g *minesGame) Name() string {
+	return "mines"
+}
+
+func (g *minesGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateMinesAllocationContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *minesGame) Commitment(result interface{}) (string, error) {
+	allocation, ok := result.(*MinesAllocation)
+	if !ok {
+		return "", errWrongResult
+	}
+	return allocation.ResultHash, nil
+}
+
+func (g *minesGame) Reveal(result interface{}) (string, error) {
+	allocation, ok := result.(*MinesAllocation)
+	if !ok {
+		return "", errWrongResult
+	}
+	return allocation.Result, nil
+}
+
+func (g *minesGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.MinesAllocationFromString(reveal)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *minesGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	result, err := g.l.VerifyMinesAllocation(reveal, commitment)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *minesGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	allocation, ok := result.(*MinesAllocation)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	minesBet, ok := bet.(*MinesBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+
+	game, err := g.l.playMinesGame(allocation, minesBet.Mines, minesBet.Reveals)
+	if err != nil {
+		return 0, err
+	}
+	return game.Coefficient, nil
+}
+
+func (g *minesGame) newBet() interface{} {
+	return &MinesBet{}
+}
+
+func (g *minesGame) newResult() interface{} {
+	return &MinesAllocation{}
+}
+
+type diceGame struct {
+	l *Logic
+}
+
+func (g *diceGame) Name() string {
+	return "dice"
+}
+
+func (g *diceGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateDiceNumberContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *diceGame) Commitment(result interface{}) (string, error) {
+	number, ok := result.(*DiceNumber)
+	if !ok {
+		return "", errWrongResult
+	}
+	return number.ResultHash, nil
+}
+
+func (g *diceGame) Reveal(result interface{}) (string, error) {
+	number, ok := result.(*DiceNumber)
+	if !ok {
+		return "", errWrongResult
+	}
+	return number.Result, nil
+}
+
+func (g *diceGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.DiceNumberFromString(reveal)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *diceGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	result, err := g.l.VerifyDiceNumber(reveal, commitment)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *diceGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	number, ok := result.(*DiceNumber)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	diceBet, ok := bet.(*DiceBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+
+	// The bet is rebuilt from its type and range, so that a decoded bet can't
+	// bring its own coefficient.
+	var err error
+	switch diceBet.Type {
+	case DiceRollUnder:
+		diceBet, err = g.l.DiceRollUnderBet(diceBet.Chance)
+	case DiceRollOver:
+		diceBet, err = g.l.DiceRollOverBet(diceBet.Chance)
+	case DiceInside:
+		diceBet, err = g.l.DiceInsideBet(diceBet.Low, diceBet.High)
+	case DiceOutside:
+		diceBet, err = g.l.DiceOutsideBet(diceBet.Low, diceBet.High)
+	default:
+		return 0, errWrongBet
+	}
+	if err != nil {
+		return 0, err
+	}
+	return g.l.SettleDiceBet(diceBet, number)
+}
+
+func (g *diceGame) newBet() interface{} {
+	return &DiceBet{}
+}
+
+func (g *diceGame) newResult() interface{} {
+	return &DiceNumber{}
+}
+
+type rouletteGame struct {
+	l *Logic
+}
+
+func (g *rouletteGame) Name() string {
+	return "roulette"
+}
+
+func (g *rouletteGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateRouletteNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *rouletteGame) Commitment(result interface{}) (string, error) {
+	number, ok := result.(*RouletteNumber)
+	if !ok {
+		return "", errWrongResult
+	}
+	return number.Signature, nil
+}
+
+func (g *rouletteGame) Reveal(result interface{}) (string, error) {
+	number, ok := result.(*RouletteNumber)
+	if !ok {
+		return "", errWrongResult
+	}
+	return number.Random, nil
+}
+
+func (g *rouletteGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.rouletteNumberFromRandom(reveal, "")
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *rouletteGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	result, err := g.l.VerifyRouletteNumber(ctx, reveal, commitment)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *rouletteGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	number, ok := result.(*RouletteNumber)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	rouletteBet, ok := bet.(*RouletteBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+
+	coefficients, err := g.l.SettleRouletteBets(number, []RouletteBet{*rouletteBet})
+	if err != nil {
+		return 0, err
+	}
+	return coefficients[0], nil
+}
+
+func (g *rouletteGame) newBet() interface{} {
+	return &RouletteBet{}
+}
+
+func (g *rouletteGame) newResult() interface{} {
+	return &RouletteNumber{}
+}
+
+// jackpotReveal is the reveal of a jackpot draw, which can't be recomputed
+// from the random object without the entries.
+type jackpotReveal struct {
+	Entries []JackpotEntry `json:"entries"`
+	Random  string         `json:"random"`
+}
+
+// jackpotGame reveals draws as the JSON of their entries and random object.
+// Its Generate always fails, as draws depend on their entries and are made
+// with GenerateJackpotDraw.
+type jackpotGame struct {
+	l *Logic
+}
+
+func (g *jackpotGame) Name() string {
+	return "jackpot"
+}
+
+func (g *jackpotGame) Generate(ctx context.Context) (interface{}, error) {
+	return nil, errors.New("wrong entries")
+}
+
+func (g *jackpotGame) Commitment(result interface{}) (string, error) {
+	draw, ok := result.(*JackpotDraw)
+	if !ok {
+		return "", errWrongResult
+	}
+	return draw.Signature, nil
+}
+
+func (g *jackpotGame) Reveal(result interface{}) (string, error) {
+	draw, ok := result.(*JackpotDraw)
+	if !ok {
+		return "", errWrongResult
+	}
+
+	reveal, err := marshal(&jackpotReveal{Entries: draw.Entries, Random: draw.Random})
+	if err != nil {
+		return "", err
+	}
+	return string(reveal), nil
+}
+
+func (g *jackpotGame) Parse(reveal string) (interface{}, error) {
+	data := &jackpotReveal{}
+	if err := unmarshal([]byte(reveal), data); err != nil {
+		return nil, err
+	}
+
+	result, err := g.l.jackpotDrawFromRandom(data.Entries, data.Random, "")
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *jackpotGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	data := &jackpotReveal{}
+	if err := unmarshal([]byte(reveal), data); err != nil {
+		return nil, err
+	}
+
+	result, err := g.l.VerifyJackpotDraw(ctx, data.Entries, data.Random, commitment)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *jackpotGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	draw, ok := result.(*JackpotDraw)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	jackpotBet, ok := bet.(*JackpotBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+
+	for _, entry := range draw.Entries {
+		if entry.Player != jackpotBet.Player {
+			continue
+		}
+
+		if draw.Winner != entry.Player {
+			return 0, nil
+		}
+		return float64(draw.Tickets) / float64(entry.Stake), nil
+	}
+	return 0, errWrongBet
+}
+
+func (g *jackpotGame) newBet() interface{} {
+	return &JackpotBet{}
+}
+
+func (g *jackpotGame) newResult() interface{} {
+	return &JackpotDraw{}
+}
+
+type limboGame struct {
+	l *Logic
+}
+
+func (g *limboGame) Name() string {
+	return "limbo"
+}
+
+func (g *limboGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateLimboResult(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *limboGame) Commitment(result interface{}) (string, error) {
+	limbo, ok := result.(*LimboResult)
+	if !ok {
+		return "", errWrongResult
+	}
+	return limbo.ResultHash, nil
+}
+
+func (g *limboGame) Reveal(result interface{}) (string, error) {
+	limbo, ok := result.(*LimboResult)
+	if !ok {
+		return "", errWrongResult
+	}
+	return limbo.Result, nil
+}
+
+func (g *limboGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.LimboResultFromString(reveal)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *limboGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	return verifySeeded(g, reveal, commitment)
+}
+
+func (g *limboGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	limbo, ok := result.(*LimboResult)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	limboBet, ok := bet.(*LimboBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+	return g.l.SettleLimbo(limboBet.Target, limbo)
+}
+
+func (g *limboGame) newBet() interface{} {
+	return &LimboBet{}
+}
+
+func (g *limboGame) newResult() interface{} {
+	return &LimboResult{}
+}
+
+type plinkoGame struct {
+	l *Logic
+}
+
+func (g *plinkoGame) Name() string {
+	return "plinko"
+}
+
+func (g *plinkoGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GeneratePlinkoPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *plinkoGame) Commitment(result interface{}) (string, error) {
+	path, ok := result.(*PlinkoPath)
+	if !ok {
+		return "", errWrongResult
+	}
+	return path.ResultHash, nil
+}
+
+func (g *plinkoGame) Reveal(result interface{}) (string, error) {
+	path, ok := result.(*PlinkoPath)
+	if !ok {
+		return "", errWrongResult
+	}
+	return path.Result, nil
+}
+
+func (g *plinkoGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.PlinkoPathFromString(reveal)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *plinkoGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	return verifySeeded(g, reveal, commitment)
+}
+
+func (g *plinkoGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	path, ok := result.(*PlinkoPath)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	plinkoBet, ok := bet.(*PlinkoBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+	return g.l.SettlePlinko(path, plinkoBet.Rows, plinkoBet.Risk)
+}
+
+func (g *plinkoGame) newBet() interface{} {
+	return &PlinkoBet{}
+}
+
+func (g *plinkoGame) newResult() interface{} {
+	return &PlinkoPath{}
+}
+
+type kenoGame struct {
+	l *Logic
+}
+
+func (g *kenoGame) Name() string {
+	return "keno"
+}
+
+func (g *kenoGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateKenoDraw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *kenoGame) Commitment(result interface{}) (string, error) {
+	draw, ok := result.(*KenoDraw)
+	if !ok {
+		return "", errWrongResult
+	}
+	return draw.ResultHash, nil
+}
+
+func (g *kenoGame) Reveal(result interface{}) (string, error) {
+	draw, ok := result.(*KenoDraw)
+	if !ok {
+		return "", errWrongResult
+	}
+	return draw.Result, nil
+}
+
+func (g *kenoGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.KenoDrawFromString(reveal)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *kenoGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	return verifySeeded(g, reveal, commitment)
+}
+
+func (g *kenoGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	draw, ok := result.(*KenoDraw)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	kenoBet, ok := bet.(*KenoBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+	return g.l.SettleKeno(draw, kenoBet.Picks, kenoBet.Risk)
+}
+
+func (g *kenoGame) newBet() interface{} {
+	return &KenoBet{}
+}
+
+func (g *kenoGame) newResult() interface{} {
+	return &KenoDraw{}
+}
+
+type coinflipGame struct {
+	l *Logic
+}
+
+func (g *coinflipGame) Name() string {
+	return "coinflip"
+}
+
+func (g *coinflipGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateCoinflipFlips(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *coinflipGame) Commitment(result interface{}) (string, error) {
+	flips, ok := result.(*CoinflipFlips)
+	if !ok {
+		return "", errWrongResult
+	}
+	return flips.ResultHash, nil
+}
+
+func (g *coinflipGame) Reveal(result interface{}) (string, error) {
+	flips, ok := result.(*CoinflipFlips)
+	if !ok {
+		return "", errWrongResult
+	}
+	return flips.Result, nil
+}
+
+func (g *coinflipGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.CoinflipFlipsFromString(reveal)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *coinflipGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	return verifySeeded(g, reveal, commitment)
+}
+
+func (g *coinflipGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	flips, ok := result.(*CoinflipFlips)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	coinflipBet, ok := bet.(*CoinflipBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+
+	streak, err := g.l.playCoinflipStreak(flips, coinflipBet.Guesses)
+	if err != nil {
+		return 0, err
+	}
+	return streak.Coefficient, nil
+}
+
+func (g *coinflipGame) newBet() interface{} {
+	return &CoinflipBet{}
+}
+
+func (g *coinflipGame) newResult() interface{} {
+	return &CoinflipFlips{}
+}
+
+// cardsGameName returns the registered name of the cards game dealt from a
+// shoe of decks decks.
+func cardsGameName(decks uint8) string {
+	return fmt.Sprintf("cards-%d", decks)
+}
+
+type cardsGame struct {
+	l     *Logic
+	decks uint8
+}
+
+func (g *cardsGame) Name() string {
+	return cardsGameName(g.decks)
+}
+
+func (g *cardsGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateCardDeck(ctx, g.decks)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *cardsGame) Commitment(result interface{}) (string, error) {
+	deck, ok := result.(*CardDeck)
+	if !ok {
+		return "", errWrongResult
+	}
+	return deck.ResultHash, nil
+}
+
+func (g *cardsGame) Reveal(result interface{}) (string, error) {
+	deck, ok := result.(*CardDeck)
+	if !ok {
+		return "", errWrongResult
+	}
+	return deck.Result, nil
+}
+
+func (g *cardsGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.CardDeckFromString(reveal)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Decks != g.decks {
+		return nil, errors.New("wrong decks count")
+	}
+	return result, nil
+}
+
+func (g *cardsGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	return verifySeeded(g, reveal, commitment)
+}
+
+func (g *cardsGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	deck, ok := result.(*CardDeck)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	cardsBet, ok := bet.(*CardsBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+
+	if cardsBet.Blackjack {
+		game, err := g.l.playBlackjackGame(deck, cardsBet.Rules, cardsBet.Actions)
+		if err != nil {
+			return 0, err
+		}
+		return game.Payout, nil
+	}
+
+	game, err := g.l.playHiLoGame(deck, cardsBet.Choices)
+	if err != nil {
+		return 0, err
+	}
+	return game.Coefficient, nil
+}
+
+func (g *cardsGame) newBet() interface{} {
+	return &CardsBet{}
+}
+
+func (g *cardsGame) newResult() interface{} {
+	return &CardDeck{}
+}
+
+// towerGameNames holds the registered name of the tower game by difficulty.
+var towerGameNames = map[TowerDifficulty]string{
+	TowerEasy:   "tower-easy",
+	TowerMedium: "tower-medium",
+	TowerHard:   "tower-hard",
+	TowerExpert: "tower-expert",
+	TowerMaster: "tower-master",
+}
+
+type towerGame struct {
+	l          *Logic
+	difficulty TowerDifficulty
+}
+
+func (g *towerGame) Name() string {
+	return towerGameNames[g.difficulty]
+}
+
+func (g *towerGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateTowerAllocation(ctx, g.difficulty)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *towerGame) Commitment(result interface{}) (string, error) {
+	allocation, ok := result.(*TowerAllocation)
+	if !ok {
+		return "", errWrongResult
+	}
+	return allocation.ResultHash, nil
+}
+
+func (g *towerGame) Reveal(result interface{}) (string, error) {
+	allocation, ok := result.(*TowerAllocation)
+	if !ok {
+		return "", errWrongResult
+	}
+	return allocation.Result, nil
+}
+
+func (g *towerGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.TowerAllocationFromString(reveal, g.difficulty)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *towerGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	return verifySeeded(g, reveal, commitment)
+}
+
+func (g *towerGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	allocation, ok := result.(*TowerAllocation)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	towerBet, ok := bet.(*TowerBet)
+	if !ok {
+		return 0, errWrongBet
+	}
+
+	game, err := g.l.playTowerGame(allocation, towerBet.Picks)
+	if err != nil {
+		return 0, err
+	}
+	return game.Coefficient, nil
+}
+
+func (g *towerGame) newBet() interface{} {
+	return &TowerBet{}
+}
+
+func (g *towerGame) newResult() interface{} {
+	return &TowerAllocation{}
+}
+
+type slotsGame struct {
+	l      *Logic
+	config *SlotConfig
+}
+
+// SlotsGame returns the game of the slot machine with config, registered
+// under the config name with Games().Register. Slot spins are settled without
+// a bet.
+func (l *Logic) SlotsGame(config *SlotConfig) (Game, error) {
+	if err := l.ValidateSlotConfig(config); err != nil {
+		return nil, err
+	}
+	return &slotsGame{l: l, config: config}, nil
+}
+
+func (g *slotsGame) Name() string {
+	return g.config.Name
+}
+
+func (g *slotsGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateSlotSpin(ctx, g.config)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *slotsGame) Commitment(result interface{}) (string, error) {
+	spin, ok := result.(*SlotSpin)
+	if !ok {
+		return "", errWrongResult
+	}
+	return spin.ResultHash, nil
+}
+
+func (g *slotsGame) Reveal(result interface{}) (string, error) {
+	spin, ok := result.(*SlotSpin)
+	if !ok {
+		return "", errWrongResult
+	}
+	return spin.Result, nil
+}
+
+func (g *slotsGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.SlotSpinFromString(g.config, reveal)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *slotsGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	return verifySeeded(g, reveal, commitment)
+}
+
+func (g *slotsGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	spin, ok := result.(*SlotSpin)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	if bet != nil {
+		return 0, errWrongBet
+	}
+
+	win, err := g.l.EvaluateSlotSpin(g.config, spin)
+	if err != nil {
+		return 0, err
+	}
+	return win.Coefficient, nil
+}
+
+func (g *slotsGame) newBet() interface{} {
+	return nil
+}
+
+func (g *slotsGame) newResult() interface{} {
+	return &SlotSpin{}
+}
+
+type caseGame struct {
+	l *Logic
+	c *Case
+}
+
+// CaseGame returns the game of the case c, registered under the case name
+// with Games().Register. Openings are settled without a bet, at the value of
+// the item divided by the case price.
+func (l *Logic) CaseGame(c *Case) (Game, error) {
+	if err := l.ValidateCase(c); err != nil {
+		return nil, err
+	}
+	return &caseGame{l: l, c: c}, nil
+}
+
+func (g *caseGame) Name() string {
+	return g.c.Name
+}
+
+func (g *caseGame) Generate(ctx context.Context) (interface{}, error) {
+	result, err := g.l.GenerateCaseOpening(ctx, g.c)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *caseGame) Commitment(result interface{}) (string, error) {
+	opening, ok := result.(*CaseOpening)
+	if !ok {
+		return "", errWrongResult
+	}
+	return opening.ResultHash, nil
+}
+
+func (g *caseGame) Reveal(result interface{}) (string, error) {
+	opening, ok := result.(*CaseOpening)
+	if !ok {
+		return "", errWrongResult
+	}
+	return opening.Result, nil
+}
+
+func (g *caseGame) Parse(reveal string) (interface{}, error) {
+	result, err := g.l.CaseOpeningFromString(g.c, reveal)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *caseGame) Verify(ctx context.Context, reveal string, commitment string) (interface{}, error) {
+	return verifySeeded(g, reveal, commitment)
+}
+
+func (g *caseGame) Settle(result interface{}, bet interface{}) (float64, error) {
+	opening, ok := result.(*CaseOpening)
+	if !ok {
+		return 0, errWrongResult
+	}
+
+	if bet != nil {
+		return 0, errWrongBet
+	}
+
+	if opening.Item < 0 || opening.Item >= len(g.c.Items) {
+		return 0, errWrongResult
+	}
+	return g.c.Items[opening.Item].Value / g.c.Price, nil
+}
+
+func (g *caseGame) newBet() interface{} {
+	return nil
+}
+
+func (g *caseGame) newResult() interface{} {
+	return &CaseOpening{}
+}
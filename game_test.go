@@ -0,0 +1,280 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGameRegistry(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+
+	expected := []string{
+		"cards-1", "cards-2", "cards-3", "cards-4", "cards-5", "cards-6", "cards-7", "cards-8",
+		"coinflip", "crash", "dice", "double", "jackpot", "keno", "limbo", "mines", "plinko", "roulette",
+		"tower-easy", "tower-expert", "tower-hard", "tower-master", "tower-medium",
+	}
+	if names := instance.Games().Names(); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected \"%v\", but got \"%v\"", expected, names)
+	}
+
+	if err := instance.Games().Register(&diceGame{instance}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.Games().Game("poker"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestGame_SeededRoundTrip(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"), WithEntropy(NewHashStream("game")))
+	ctx := context.Background()
+
+	slots, err := instance.SlotsGame(slotTestConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := instance.Games().Register(slots); err != nil {
+		t.Fatal(err)
+	}
+	c, err := instance.CaseGame(caseTestCase())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := instance.Games().Register(c); err != nil {
+		t.Fatal(err)
+	}
+
+	bets := map[string]interface{}{
+		"mines":             &MinesBet{Mines: 24, Reveals: []uint8{1}},
+		"dice":              &DiceBet{Type: DiceRollUnder, Chance: 5000, Low: 0, High: 500000, Coefficient: 1.9},
+		"limbo":             &LimboBet{Target: 2},
+		"plinko":            &PlinkoBet{Rows: 16, Risk: PlinkoHigh},
+		"keno":              &KenoBet{Picks: []uint8{1, 2, 3}, Risk: KenoLow},
+		"coinflip":          &CoinflipBet{Guesses: []uint8{CoinflipHeads}},
+		"cards-1":           &CardsBet{Choices: []HiLoChoice{HiLoHigher}},
+		"cards-6":           &CardsBet{Blackjack: true, Rules: BlackjackRules{Decks: 6}, Actions: []BlackjackAction{BlackjackStand}},
+		"tower-hard":        &TowerBet{Picks: []uint8{1}},
+		"tower-easy":        &TowerBet{Picks: []uint8{1}},
+		"slots":             nil,
+		caseTestCase().Name: nil,
+	}
+
+	// Games draw from the same entropy stream, so they run in a fixed order to
+	// deal the same results every time.
+	names := make([]string, 0, len(bets))
+	for name := range bets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		bet := bets[name]
+		game, err := instance.Games().Game(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := game.Generate(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitment, err := game.Commitment(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reveal, err := game.Reveal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		verified, err := game.Verify(ctx, reveal, commitment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(verified, result) {
+			t.Fatalf("expected \"%+v\", but got \"%+v\"", result, verified)
+		}
+
+		parsed, err := game.Parse(reveal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(parsed, result) {
+			t.Fatalf("expected \"%+v\", but got \"%+v\"", result, parsed)
+		}
+
+		if _, err := game.Verify(ctx, reveal, strings.Repeat("0", 128)); err == nil {
+			t.Fatalf("expected error but got nil")
+		}
+
+		if _, err := game.Settle(result, bet); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := game.Settle(result, &CrashBet{}); err == nil {
+			t.Fatalf("expected error but got nil")
+		}
+	}
+}
+
+func TestGame_CardsDecks(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	ctx := context.Background()
+
+	shoe, err := instance.Games().Game("cards-6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := shoe.Generate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deck := result.(*CardDeck); deck.Decks != 6 || len(deck.Cards) != 6*DeckLength {
+		t.Fatalf("expected 6 decks, but got %d", deck.Decks)
+	}
+
+	reveal, err := shoe.Reveal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := instance.Games().Game("cards-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := single.Parse(reveal); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestGame_SignedSettle(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+
+	crash, err := instance.Games().Game("crash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	coef, err := crash.Parse(`{"method":"generateSignedDecimalFractions","n":1,"decimalPlaces":3,"data":[0.5],"serialNumber":42}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if win, err := crash.Settle(coef, &CrashBet{Target: 1.5}); err != nil || win != 1.5 {
+		t.Fatalf("expected 1.5, but got %v %v", win, err)
+	}
+	if win, err := crash.Settle(coef, &CrashBet{Target: 3}); err != nil || win != 0 {
+		t.Fatalf("expected 0, but got %v %v", win, err)
+	}
+
+	double, err := instance.Games().Game("double")
+	if err != nil {
+		t.Fatal(err)
+	}
+	number, err := double.Parse(`{"method":"generateSignedIntegers","n":1,"min":0,"max":53,"data":[52],"serialNumber":42}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if win, err := double.Settle(number, &DoubleBet{Coefficient: 50}); err != nil || win != 50 {
+		t.Fatalf("expected 50, but got %v %v", win, err)
+	}
+	if win, err := double.Settle(number, &DoubleBet{Coefficient: 2}); err != nil || win != 0 {
+		t.Fatalf("expected 0, but got %v %v", win, err)
+	}
+
+	if _, err := double.Parse(`{"method":"generateSignedIntegers","n":1,"min":0,"max":53,"data":[54],"serialNumber":42}`); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	roulette, err := instance.Games().Game("roulette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	number, err = roulette.Parse(`{"method":"generateSignedIntegers","n":1,"min":0,"max":36,"data":[17],"serialNumber":42}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if win, err := roulette.Settle(number, &RouletteBet{Type: RouletteStraight, Numbers: []uint8{17}}); err != nil || win != 36 {
+		t.Fatalf("expected 36, but got %v %v", win, err)
+	}
+	if win, err := roulette.Settle(number, &RouletteBet{Type: RouletteEven}); err != nil || win != 0 {
+		t.Fatalf("expected 0, but got %v %v", win, err)
+	}
+
+	jackpot, err := instance.Games().Game("jackpot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	draw, err := jackpot.Parse(`{"entries":[{"player":"a","stake":1},{"player":"b","stake":3}],` +
+		`"random":"{\"method\":\"generateSignedDecimalFractions\",\"n\":1,\"decimalPlaces\":8,\"data\":[0.5],\"serialNumber\":42}"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if win, err := jackpot.Settle(draw, &JackpotBet{Player: "b"}); err != nil || win != 4.0/3 {
+		t.Fatalf("expected %v, but got %v %v", 4.0/3, win, err)
+	}
+	if win, err := jackpot.Settle(draw, &JackpotBet{Player: "a"}); err != nil || win != 0 {
+		t.Fatalf("expected 0, but got %v %v", win, err)
+	}
+	if _, err := jackpot.Settle(draw, &JackpotBet{Player: "c"}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	reveal, err := jackpot.Reveal(draw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed, err := jackpot.Parse(reveal); err != nil || !reflect.DeepEqual(parsed, draw) {
+		t.Fatalf("expected \"%+v\", but got \"%+v\" %v", draw, parsed, err)
+	}
+}
+
+func TestLogic_DecodeBet(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+
+	bet, err := instance.DecodeBet("mines", []byte(`{"mines":2,"reveals":[3,7]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bet, &MinesBet{Mines: 2, Reveals: []uint8{3, 7}}) {
+		t.Fatalf("expected \"&{2 [3 7]}\", but got \"%+v\"", bet)
+	}
+
+	if _, err := instance.DecodeBet("mines", []byte(`[]`)); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.DecodeBet("poker", []byte(`{}`)); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+type testGame struct {
+	diceGame
+}
+
+func (g *testGame) Name() string {
+	return "test"
+}
+
+func TestLogic_VerifyHandlerGame(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	if err := instance.Games().Register(&testGame{diceGame{instance}}); err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"reveal":"6bdp5eu5rtbwr87dnlxlpa2yj00598zlahnj|914655|fb79o8tqteia8s4on98imrrslpfpun7c9q31",` +
+		`"commitment":"54504a11f613b1ec748e41e7efb5aefc37cb951676c6e5db576a308d5067f806b4a4a712148878c1c6fe20c056275a439f7ce8e10aa01a915bb1c665f5776ce6"}`
+
+	recorder := serveVerify(instance.VerifyHandler(), http.MethodPost, "/test", body)
+
+	decoded, _ := verifyResponse(t, recorder)
+	if decoded.(*DiceNumber).Value != 914655 {
+		t.Fatalf("expected 914655, but got %d", decoded.(*DiceNumber).Value)
+	}
+}
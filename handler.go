@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gameVerifyRequest settles Bet, the JSON of the bet of the game as decoded
+// by DecodeBet, when it is given.
+type gameVerifyRequest struct {
+	Reveal     string          `json:"reveal"`
+	Commitment string          `json:"commitment"`
+	Bet        json.RawMessage `json:"bet"`
+}
+
+// gameVerifyResponse holds the encoded result, and the coefficient of the bet
+// when one was given, 0 when it lost.
+type gameVerifyResponse struct {
+	Result      interface{} `json:"result"`
+	Coefficient *float64    `json:"coefficient,omitempty"`
+}
+
+type verifyErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeVerifyJSON(w http.ResponseWriter, status int, data interface{}) {
+	body, err := marshal(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// VerifyHandler returns a handler with a POST endpoint /{game} for every game
+// of the registry. It takes the reveal, the commitment and optionally the bet,
+// and answers with the encoded result and the coefficient of the bet. It keeps
+// no state, so it can be mounted anywhere with http.StripPrefix.
+//
+// The handler never calls random.org, so that its quota can't be drained by
+// requests. Signatures are checked against the key set with
+// WithSignatureKey, without one they always fail. Malformed requests are
+// answered with 400 and failed verifications with 422.
+func (l *Logic) VerifyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		game, err := l.games.Game(name)
+		if err != nil {
+			writeVerifyJSON(w, http.StatusNotFound, &verifyErrorResponse{Error: err.Error()})
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeVerifyJSON(w, http.StatusMethodNotAllowed, &verifyErrorResponse{Error: "method not allowed"})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<16))
+		if err != nil {
+			writeVerifyJSON(w, http.StatusBadRequest, &verifyErrorResponse{Error: err.Error()})
+			return
+		}
+
+		data := &gameVerifyRequest{}
+		if err := unmarshal(body, data); err != nil {
+			writeVerifyJSON(w, http.StatusBadRequest, &verifyErrorResponse{Error: "wrong request body"})
+			return
+		}
+
+		response, err := l.verifyGameRequest(withLocalSignatures(r.Context()), game, data)
+		if err != nil {
+			writeVerifyJSON(w, http.StatusUnprocessableEntity, &verifyErrorResponse{Error: err.Error()})
+			return
+		}
+		writeVerifyJSON(w, http.StatusOK, response)
+	})
+}
+
+func (l *Logic) verifyGameRequest(ctx context.Context, game Game, data *gameVerifyRequest) (*gameVerifyResponse, error) {
+	result, err := game.Verify(ctx, data.Reveal, data.Commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &gameVerifyResponse{Result: result}
+	if len(data.Bet) == 0 {
+		return response, nil
+	}
+
+	bet, err := l.DecodeBet(game.Name(), data.Bet)
+	if err != nil {
+		return nil, err
+	}
+
+	coefficient, err := game.Settle(result, bet)
+	if err != nil {
+		return nil, err
+	}
+	response.Coefficient = &coefficient
+	return response, nil
+}
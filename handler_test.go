@@ -0,0 +1,168 @@
+package logic
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func serveVerify(handler http.Handler, method string, path string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return recorder
+}
+
+// verifyResponse decodes the body of the verify handler, with the encoded
+// result left for DecodeResult.
+func verifyResponse(t *testing.T, recorder *httptest.ResponseRecorder) (interface{}, *float64) {
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected %d, but got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	result := &json.RawMessage{}
+	response := &gameVerifyResponse{Result: result}
+	if err := unmarshal(recorder.Body.Bytes(), response); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := New("").DecodeResult(*result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded, response.Coefficient
+}
+
+func TestLogic_VerifyHandlerMines(t *testing.T) {
+	handler := New(os.Getenv("API_KEY")).VerifyHandler()
+	body := `{"reveal":"s828mk09wr|6|19|13|23|4|16|14|5|9|12|22|21|8|24|25|7|18|10|1|20|17|15|11|3|2|xbfb1t3bgj",` +
+		`"commitment":"8c9bf431e0cbcb1d77dd23e8f03ef01237dbd0f77b166279752a1fb110432d760e7d82affef11dcf65a489f126b2ed7f8b2b768ddbaac3aaa4f0544ea9dad53f",` +
+		`"bet":{"mines":2,"reveals":[13,23]}}`
+
+	result, coefficient := verifyResponse(t, serveVerify(handler, http.MethodPost, "/mines", body))
+	allocation := result.(*MinesAllocation)
+	if len(allocation.Places) != 25 || allocation.Places[0] != 6 || allocation.Places[1] != 19 {
+		t.Fatalf("expected mines \"[6 19]\", but got \"%v\"", allocation.Places)
+	}
+
+	coefficients, err := New(os.Getenv("API_KEY")).GenerateMinesCoefficients(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coefficient == nil || *coefficient != coefficients[1] {
+		t.Fatalf("expected %v, but got %v", coefficients[1], coefficient)
+	}
+}
+
+func TestLogic_VerifyHandlerDice(t *testing.T) {
+	handler := New(os.Getenv("API_KEY")).VerifyHandler()
+	body := `{"reveal":"6bdp5eu5rtbwr87dnlxlpa2yj00598zlahnj|914655|fb79o8tqteia8s4on98imrrslpfpun7c9q31",` +
+		`"commitment":"54504a11f613b1ec748e41e7efb5aefc37cb951676c6e5db576a308d5067f806b4a4a712148878c1c6fe20c056275a439f7ce8e10aa01a915bb1c665f5776ce6"}`
+
+	result, coefficient := verifyResponse(t, serveVerify(handler, http.MethodPost, "/dice", body))
+	if result.(*DiceNumber).Value != 914655 {
+		t.Fatalf("expected 914655, but got %d", result.(*DiceNumber).Value)
+	}
+	if coefficient != nil {
+		t.Fatalf("expected no coefficient, but got %v", *coefficient)
+	}
+}
+
+func signRandom(t *testing.T, key *rsa.PrivateKey, random string) string {
+	digest := sha512.Sum512([]byte(random))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestLogic_VerifyHandlerCrash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected random.org request")
+	}))
+	url := ApiUrl
+	ApiUrl = server.URL
+	defer func() {
+		ApiUrl = url
+		server.Close()
+	}()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	random := `{"method":"generateSignedDecimalFractions","n":1,"decimalPlaces":3,"data":[0.5],"serialNumber":42}`
+	request, err := marshal(&gameVerifyRequest{Reveal: random, Commitment: signRandom(t, key, random), Bet: json.RawMessage(`{"target":1.5}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := New(os.Getenv("API_KEY"), WithSignatureKey(&key.PublicKey)).VerifyHandler()
+	result, coefficient := verifyResponse(t, serveVerify(handler, http.MethodPost, "/crash", string(request)))
+	if coef := result.(*CrashCoefficient); coef.Value != crashFloor(0.5) || coef.SerialNumber != 42 {
+		t.Fatalf("expected %v #42, but got %v #%d", crashFloor(0.5), coef.Value, coef.SerialNumber)
+	}
+	if coefficient == nil || *coefficient != 1.5 {
+		t.Fatalf("expected 1.5, but got %v", coefficient)
+	}
+
+	wrong, err := marshal(&gameVerifyRequest{Reveal: random, Commitment: signRandom(t, key, random+" ")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder := serveVerify(handler, http.MethodPost, "/crash", string(wrong)); recorder.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected %d, but got %d", http.StatusUnprocessableEntity, recorder.Code)
+	}
+
+	handler = New(os.Getenv("API_KEY")).VerifyHandler()
+	if recorder := serveVerify(handler, http.MethodPost, "/crash", string(request)); recorder.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected %d, but got %d", http.StatusUnprocessableEntity, recorder.Code)
+	}
+}
+
+func TestLogic_VerifyHandlerDiceLost(t *testing.T) {
+	handler := New(os.Getenv("API_KEY")).VerifyHandler()
+	body := `{"reveal":"6bdp5eu5rtbwr87dnlxlpa2yj00598zlahnj|914655|fb79o8tqteia8s4on98imrrslpfpun7c9q31",` +
+		`"commitment":"54504a11f613b1ec748e41e7efb5aefc37cb951676c6e5db576a308d5067f806b4a4a712148878c1c6fe20c056275a439f7ce8e10aa01a915bb1c665f5776ce6",` +
+		`"bet":{"type":0,"chance":4950,"coefficient":1000}}`
+
+	recorder := serveVerify(handler, http.MethodPost, "/dice", body)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected %d, but got %d", http.StatusOK, recorder.Code)
+	}
+	if !strings.HasSuffix(recorder.Body.String(), `,"coefficient":0}`) {
+		t.Fatalf("expected a zero coefficient, but got %s", recorder.Body.String())
+	}
+}
+
+func TestLogic_VerifyHandlerErrors(t *testing.T) {
+	handler := New(os.Getenv("API_KEY")).VerifyHandler()
+
+	if recorder := serveVerify(handler, http.MethodGet, "/dice", ""); recorder.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, but got %d", http.StatusMethodNotAllowed, recorder.Code)
+	}
+	if recorder := serveVerify(handler, http.MethodPost, "/dice", "{"); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, but got %d", http.StatusBadRequest, recorder.Code)
+	}
+	if recorder := serveVerify(handler, http.MethodPost, "/dice", `{"reveal":"a|1|b","commitment":"wrong"}`); recorder.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected %d, but got %d", http.StatusUnprocessableEntity, recorder.Code)
+	}
+	if recorder := serveVerify(handler, http.MethodPost, "/unknown", "{}"); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected %d, but got %d", http.StatusNotFound, recorder.Code)
+	}
+
+	body := `{"reveal":"6bdp5eu5rtbwr87dnlxlpa2yj00598zlahnj|914655|fb79o8tqteia8s4on98imrrslpfpun7c9q31",` +
+		`"commitment":"54504a11f613b1ec748e41e7efb5aefc37cb951676c6e5db576a308d5067f806b4a4a712148878c1c6fe20c056275a439f7ce8e10aa01a915bb1c665f5776ce6","bet":[]}`
+	if recorder := serveVerify(handler, http.MethodPost, "/dice", body); recorder.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected %d, but got %d", http.StatusUnprocessableEntity, recorder.Code)
+	}
+}
@@ -0,0 +1,188 @@
+package logic
+
+import (
+	"errors"
+	"strings"
+)
+
+type HiLoChoice uint8
+
+const (
+	HiLoHigher HiLoChoice = iota
+	HiLoLower
+	HiLoEqual
+	HiLoSkip
+)
+
+// HiLoGame is played on a single shuffled deck, Position is the index of the
+// card on the table and Chances holds the probability of every won guess.
+type HiLoGame struct {
+	Deck        *CardDeck
+	Position    int
+	Chances     []float64
+	Finished    bool
+	Won         bool
+	Coefficient float64
+}
+
+func (l *Logic) NewHiLoGame(deck *CardDeck) (*HiLoGame, error) {
+	if deck.Decks != 1 || len(deck.Cards) != DeckLength {
+		return nil, errors.New("wrong deck")
+	}
+
+	game := &HiLoGame{
+		Deck: deck,
+	}
+	return game, nil
+}
+
+func (g *HiLoGame) Current() Card {
+	return g.Deck.Cards[g.Position]
+}
+
+// HiLoChance returns the exact probability that the next card matches the
+// choice, counted over the cards not dealt yet.
+func (l *Logic) HiLoChance(game *HiLoGame, choice HiLoChoice) (float64, error) {
+	if game.Finished || game.Position+1 >= len(game.Deck.Cards) {
+		return 0, errors.New("game is finished")
+	}
+
+	current := game.Current().Rank()
+	remaining := game.Deck.Cards[game.Position+1:]
+
+	var count int
+	for _, card := range remaining {
+		rank := card.Rank()
+		switch choice {
+		case HiLoHigher:
+			if rank > current {
+				count++
+			}
+		case HiLoLower:
+			if rank < current {
+				count++
+			}
+		case HiLoEqual:
+			if rank == current {
+				count++
+			}
+		case HiLoSkip:
+			count++
+		default:
+			return 0, errors.New("wrong choice")
+		}
+	}
+
+	return float64(count) / float64(len(remaining)), nil
+}
+
+// HiLoCoefficientByChoice returns the cashout coefficient the game reaches if
+// the choice wins.
+func (l *Logic) HiLoCoefficientByChoice(game *HiLoGame, choice HiLoChoice) (float64, error) {
+	chance, err := l.HiLoChance(game, choice)
+	if err != nil {
+		return 0, err
+	}
+
+	if choice == HiLoSkip {
+		return game.Coefficient, nil
+	}
+
+	if chance == 0 {
+		return 0, errors.New("wrong choice")
+	}
+
+	chances := append(append([]float64(nil), game.Chances...), chance)
+	coefficients := l.survivalCoefficients(chances)
+	return coefficients[len(coefficients)-1], nil
+}
+
+func (l *Logic) HiLoStep(game *HiLoGame, choice HiLoChoice) error {
+	coefficient, err := l.HiLoCoefficientByChoice(game, choice)
+	if err != nil {
+		return err
+	}
+
+	chance, _ := l.HiLoChance(game, choice)
+
+	current := game.Current().Rank()
+	game.Position++
+	next := game.Current().Rank()
+
+	won := choice == HiLoSkip ||
+		(choice == HiLoHigher && next > current) ||
+		(choice == HiLoLower && next < current) ||
+		(choice == HiLoEqual && next == current)
+	if !won {
+		game.Finished = true
+		game.Coefficient = 0
+		return nil
+	}
+
+	if choice != HiLoSkip {
+		game.Chances = append(game.Chances, chance)
+		game.Coefficient = coefficient
+	}
+	return nil
+}
+
+func (l *Logic) HiLoCashout(game *HiLoGame) (float64, error) {
+	if game.Finished {
+		return 0, errors.New("game is finished")
+	}
+
+	if len(game.Chances) == 0 {
+		return 0, errors.New("nothing to cash out")
+	}
+
+	game.Finished = true
+	game.Won = true
+	return game.Coefficient, nil
+}
+
+// VerifyHiLoGame replays the choices on the revealed deck. A game that is not
+// lost after the last choice is treated as cashed out, and a game without a
+// guess, e.g. of skips only, settles with a zero coefficient.
+func (l *Logic) VerifyHiLoGame(result string, resultHash string, choices []HiLoChoice) (*HiLoGame, error) {
+	deck, err := l.CardDeckFromString(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if deck.ResultHash != strings.ToLower(resultHash) {
+		return nil, errors.New("wrong result hash")
+	}
+
+	return l.playHiLoGame(deck, choices)
+}
+
+func (l *Logic) playHiLoGame(deck *CardDeck, choices []HiLoChoice) (*HiLoGame, error) {
+	game, err := l.NewHiLoGame(deck)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, choice := range choices {
+		if err := l.HiLoStep(game, choice); err != nil {
+			return nil, err
+		}
+
+		if game.Finished {
+			if i+1 < len(choices) {
+				return nil, errors.New("wrong choice sequence")
+			}
+			return game, nil
+		}
+	}
+
+	if len(game.Chances) == 0 {
+		game.Finished = true
+		game.Coefficient = 0
+		return game, nil
+	}
+
+	if _, err := l.HiLoCashout(game); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
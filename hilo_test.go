@@ -0,0 +1,101 @@
+package logic
+
+import (
+	"os"
+	"testing"
+)
+
+func hiLoTestDeck() string {
+	cards := []Card{6, 12, 0}
+	for c := Card(1); c < DeckLength; c++ {
+		if c != 6 && c != 12 {
+			cards = append(cards, c)
+		}
+	}
+	return "left|" + joinCards(cards, "|") + "|right"
+}
+
+func TestLogic_HiLoStep(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	deck, err := instance.CardDeckFromString(hiLoTestDeck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, err := instance.NewHiLoGame(deck)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chance, err := instance.HiLoChance(game, HiLoHigher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chance != 24.0/51 {
+		t.Fatalf("expected %v, but got %v", 24.0/51, chance)
+	}
+
+	if err := instance.HiLoStep(game, HiLoHigher); err != nil {
+		t.Fatal(err)
+	}
+	if game.Finished || game.Coefficient != 2.02 {
+		t.Fatalf("expected 2.02, but got %v", game.Coefficient)
+	}
+
+	if err := instance.HiLoStep(game, HiLoLower); err != nil {
+		t.Fatal(err)
+	}
+	if game.Finished || game.Coefficient != 2.15 {
+		t.Fatalf("expected 2.15, but got %v", game.Coefficient)
+	}
+
+	if _, err := instance.HiLoCoefficientByChoice(game, HiLoLower); err == nil {
+		t.Fatalf("expected error for lower than ace but got nil")
+	}
+
+	if err := instance.HiLoStep(game, HiLoEqual); err != nil {
+		t.Fatal(err)
+	}
+	if !game.Finished || game.Won || game.Coefficient != 0 {
+		t.Fatalf("expected loss, but got %v with %v", game.Won, game.Coefficient)
+	}
+	if _, err := instance.HiLoCashout(game); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_VerifyHiLoGame(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	result := hiLoTestDeck()
+	resultHash := hashResult(result)
+
+	game, err := instance.VerifyHiLoGame(result, resultHash, []HiLoChoice{HiLoHigher, HiLoSkip, HiLoHigher})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !game.Won || game.Position != 3 || len(game.Chances) != 2 {
+		t.Fatalf("expected cashout at 3 after 2 guesses, but got %v at %d", game.Won, game.Position)
+	}
+
+	game, err = instance.VerifyHiLoGame(result, resultHash, []HiLoChoice{HiLoLower})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Won || !game.Finished {
+		t.Fatalf("expected loss, but got %v", game.Won)
+	}
+
+	game, err = instance.VerifyHiLoGame(result, resultHash, []HiLoChoice{HiLoSkip, HiLoSkip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Won || !game.Finished || game.Coefficient != 0 || game.Position != 2 {
+		t.Fatalf("expected zero coefficient at 2, but got %v with %v at %d", game.Won, game.Coefficient, game.Position)
+	}
+
+	if _, err := instance.VerifyHiLoGame(result, resultHash, []HiLoChoice{HiLoLower, HiLoHigher}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyHiLoGame(result, "wrong", []HiLoChoice{HiLoHigher}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
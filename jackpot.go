@@ -0,0 +1,166 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+)
+
+type JackpotEntry struct {
+	Player string `json:"player"`
+	Stake  uint64 `json:"stake"`
+}
+
+// JackpotDraw is the verification record of a draw. Entries are sorted by
+// player and each one owns Stake tickets following the previous entry. The
+// winning Ticket is floor(D * Tickets / 10^8), where D is the signed decimal
+// value with 8 decimal places multiplied by 10^8.
+//
+// D has 10^8 outcomes, so each ticket wins with floor(10^8 / Tickets) or
+// ceil(10^8 / Tickets) of them. The draw is exactly uniform only when Tickets
+// divides 10^8, otherwise a ticket's chance is off by less than 1 / 10^8.
+// Draws with more than 10^8 tickets are rejected, as some tickets could never
+// win.
+type JackpotDraw struct {
+	Entries      []JackpotEntry
+	Tickets      uint64
+	Value        float64
+	Ticket       uint64
+	Winner       string
+	Random       string
+	Signature    string
+	SerialNumber uint64
+}
+
+const jackpotScale uint64 = 100000000
+
+func jackpotEntries(entries []JackpotEntry) ([]JackpotEntry, uint64, error) {
+	if len(entries) == 0 {
+		return nil, 0, errors.New("wrong entries")
+	}
+
+	sorted := append([]JackpotEntry(nil), entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Player < sorted[j].Player })
+
+	var tickets uint64
+	for i, entry := range sorted {
+		if entry.Stake == 0 || (i > 0 && sorted[i-1].Player == entry.Player) {
+			return nil, 0, errors.New("wrong entries")
+		}
+		if tickets+entry.Stake < tickets {
+			return nil, 0, errors.New("wrong entries")
+		}
+		tickets += entry.Stake
+	}
+
+	if tickets > jackpotScale {
+		return nil, 0, errors.New("too many tickets")
+	}
+	return sorted, tickets, nil
+}
+
+func (l *Logic) jackpotDraw(entries []JackpotEntry, decimal *Decimal) (*JackpotDraw, error) {
+	sorted, tickets, err := jackpotEntries(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	if decimal.Value < 0 || decimal.Value >= 1 {
+		return nil, errors.New("wrong jackpot value")
+	}
+
+	value := uint64(math.Round(decimal.Value * float64(jackpotScale)))
+	if value >= jackpotScale {
+		return nil, errors.New("wrong jackpot value")
+	}
+	hi, lo := bits.Mul64(value, tickets)
+	ticket, _ := bits.Div64(hi, lo, jackpotScale)
+
+	draw := &JackpotDraw{
+		Entries:      sorted,
+		Tickets:      tickets,
+		Value:        decimal.Value,
+		Ticket:       ticket,
+		Random:       decimal.Random,
+		Signature:    decimal.Signature,
+		SerialNumber: decimal.SerialNumber,
+	}
+
+	var start uint64
+	for _, entry := range sorted {
+		if ticket < start+entry.Stake {
+			draw.Winner = entry.Player
+			break
+		}
+		start += entry.Stake
+	}
+	return draw, nil
+}
+
+func (l *Logic) GenerateJackpotDraw(ctx context.Context, entries []JackpotEntry) (*JackpotDraw, error) {
+	startedAt := time.Now()
+	draw, err := l.generateJackpotDraw(ctx, entries)
+	l.observeGeneration("jackpot", draw, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSigned(ctx, draw.Random, draw.SerialNumber, draw); err != nil {
+		return nil, err
+	}
+	return draw, nil
+}
+
+func (l *Logic) generateJackpotDraw(ctx context.Context, entries []JackpotEntry) (*JackpotDraw, error) {
+	if _, _, err := jackpotEntries(entries); err != nil {
+		return nil, err
+	}
+
+	decimal, err := l.api.GenerateDecimal(ctx, 8)
+	if err != nil {
+		return nil, fmt.Errorf("random.org api error: %v", err)
+	}
+
+	draw, err := l.jackpotDraw(entries, decimal)
+	if err != nil {
+		return nil, err
+	}
+	return draw, nil
+}
+
+// VerifyJackpotDraw checks the random.org signature of the random object and
+// recomputes the draw from it.
+func (l *Logic) VerifyJackpotDraw(ctx context.Context, entries []JackpotEntry, random string, signature string) (*JackpotDraw, error) {
+	draw, err := l.jackpotDrawFromRandom(entries, random, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.verifySignature(ctx, random, signature); err != nil {
+		return nil, err
+	}
+	return draw, nil
+}
+
+func (l *Logic) jackpotDrawFromRandom(entries []JackpotEntry, random string, signature string) (*JackpotDraw, error) {
+	data := &decimalResponseRandom{}
+	if err := unmarshal([]byte(random), data); err != nil {
+		return nil, err
+	}
+
+	if data.Method != "generateSignedDecimalFractions" || len(data.Data) != 1 || data.DecimalPlaces != 8 {
+		return nil, errors.New("wrong random")
+	}
+
+	decimal := &Decimal{
+		Value:        data.Data[0],
+		Random:       random,
+		Signature:    signature,
+		SerialNumber: data.SerialNumber,
+	}
+	return l.jackpotDraw(entries, decimal)
+}
@@ -0,0 +1,78 @@
+package logic
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLogic_GenerateJackpotDrawWrongKey(t *testing.T) {
+	instance := New("")
+	_, err := instance.GenerateJackpotDraw(context.Background(), []JackpotEntry{{Player: "a", Stake: 1}})
+	if err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_VerifyJackpotDraw(t *testing.T) {
+	defer withSignatureServer(t, "true")()
+
+	instance := New("")
+	entries := []JackpotEntry{
+		{Player: "carol", Stake: 500},
+		{Player: "alice", Stake: 100},
+		{Player: "bob", Stake: 400},
+	}
+
+	cases := map[string]string{
+		"0":          "alice",
+		"0.09999999": "alice",
+		"0.1":        "bob",
+		"0.49999999": "bob",
+		"0.5":        "carol",
+		"0.99999999": "carol",
+	}
+	for value, winner := range cases {
+		random := `{"method":"generateSignedDecimalFractions","n":1,"decimalPlaces":8,"data":[` + value + `],"serialNumber":7}`
+		draw, err := instance.VerifyJackpotDraw(context.Background(), entries, random, "signature")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if draw.Winner != winner {
+			t.Fatalf("expected \"%s\" for %s, but got \"%s\" with ticket %d", winner, value, draw.Winner, draw.Ticket)
+		}
+		if draw.Tickets != 1000 || draw.SerialNumber != 7 || draw.Entries[0].Player != "alice" {
+			t.Fatalf("unexpected record %v", draw)
+		}
+	}
+}
+
+func TestLogic_VerifyJackpotDrawWrongInput(t *testing.T) {
+	defer withSignatureServer(t, "false")()
+
+	ctx := context.Background()
+	instance := New("")
+	random := `{"method":"generateSignedDecimalFractions","n":1,"decimalPlaces":8,"data":[0.5],"serialNumber":7}`
+
+	if _, err := instance.VerifyJackpotDraw(ctx, nil, random, ""); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	duplicate := []JackpotEntry{{Player: "a", Stake: 1}, {Player: "a", Stake: 2}}
+	if _, err := instance.VerifyJackpotDraw(ctx, duplicate, random, ""); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyJackpotDraw(ctx, []JackpotEntry{{Player: "a", Stake: 0}}, random, ""); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyJackpotDraw(ctx, []JackpotEntry{{Player: "a", Stake: 1}}, `{"method":"generateSignedDecimalFractions","n":1,"decimalPlaces":3,"data":[0.5]}`, ""); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyJackpotDraw(ctx, []JackpotEntry{{Player: "a", Stake: 1}}, `{"method":"generateDecimalFractions","n":1,"decimalPlaces":8,"data":[0.5]}`, ""); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyJackpotDraw(ctx, []JackpotEntry{{Player: "a", Stake: jackpotScale + 1}}, random, ""); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyJackpotDraw(ctx, []JackpotEntry{{Player: "a", Stake: 1}}, random, "signature"); err == nil || err.Error() != "wrong signature" {
+		t.Fatalf("expected wrong signature, but got %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type KenoRisk uint8
+
+const (
+	KenoLow KenoRisk = iota
+	KenoMedium
+	KenoHigh
+)
+
+const (
+	KenoNumbers  uint8 = 40
+	KenoDrawn    uint8 = 10
+	KenoMaxPicks uint8 = 10
+)
+
+type KenoDraw struct {
+	Numbers    []uint8
+	LeftSeed   string
+	RightSeed  string
+	Result     string
+	ResultHash string
+}
+
+// kenoWeights are the shapes of the payout tables by risk and picks starting
+// from 1, indexed by hits. GenerateKenoCoefficients scales them to the house
+// edge.
+var kenoWeights = map[KenoRisk][][]float64{
+	KenoLow: {
+		{0.7, 1.85},
+		{0, 2, 3.8},
+		{0, 1.1, 1.38, 26},
+		{0, 0, 2.2, 7.9, 90},
+		{0, 0, 1.5, 4.2, 13, 300},
+		{0, 0, 1.1, 2, 6.2, 100, 700},
+		{0, 0, 1.1, 1.6, 3.5, 15, 225, 700},
+		{0, 0, 1.1, 1.5, 2, 5.5, 39, 100, 800},
+		{0, 0, 1.1, 1.3, 1.7, 2.5, 7.5, 50, 250, 1000},
+		{0, 0, 1.1, 1.2, 1.3, 1.8, 3.5, 13, 50, 250, 1000},
+	},
+	KenoMedium: {
+		{0.4, 2.75},
+		{0, 1.8, 5.1},
+		{0, 0, 2.8, 50},
+		{0, 0, 1.7, 10, 100},
+		{0, 0, 1.4, 4, 14, 390},
+		{0, 0, 0, 3, 9, 180, 710},
+		{0, 0, 0, 2, 7, 30, 400, 800},
+		{0, 0, 0, 2, 4, 11, 67, 400, 900},
+		{0, 0, 0, 2, 2.5, 5, 15, 100, 500, 1000},
+		{0, 0, 0, 1.6, 2, 4, 7, 26, 100, 500, 1000},
+	},
+	KenoHigh: {
+		{0, 3.96},
+		{0, 0, 17.1},
+		{0, 0, 0, 81.5},
+		{0, 0, 0, 10, 259},
+		{0, 0, 0, 4.5, 48, 450},
+		{0, 0, 0, 0, 11, 350, 710},
+		{0, 0, 0, 0, 7, 90, 400, 800},
+		{0, 0, 0, 0, 5, 20, 270, 600, 900},
+		{0, 0, 0, 0, 4, 11, 56, 500, 800, 1000},
+		{0, 0, 0, 0, 3.5, 8, 13, 63, 500, 800, 1000},
+	},
+}
+
+func (l *Logic) kenoWeights(picks uint8, risk KenoRisk) ([]float64, error) {
+	if picks < 1 || picks > KenoMaxPicks {
+		return nil, errors.New("wrong picks count")
+	}
+
+	tables, ok := kenoWeights[risk]
+	if !ok {
+		return nil, errors.New("wrong risk")
+	}
+
+	return tables[picks-1], nil
+}
+
+func combinations(n uint64, k uint64) uint64 {
+	if k > n {
+		return 0
+	}
+
+	var result uint64 = 1
+	for i := uint64(1); i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
+
+// kenoChances returns how many of the C(40, 10) draws give each number of
+// hits for a ticket with picks numbers.
+func kenoChances(picks uint8) []uint64 {
+	chances := make([]uint64, picks+1)
+	for hits := range chances {
+		chances[hits] = combinations(uint64(picks), uint64(hits)) *
+			combinations(uint64(KenoNumbers-picks), uint64(KenoDrawn)-uint64(hits))
+	}
+	return chances
+}
+
+func (l *Logic) GenerateKenoCoefficients(picks uint8, risk KenoRisk) ([]float64, error) {
+	weights, err := l.kenoWeights(picks, risk)
+	if err != nil {
+		return nil, err
+	}
+
+	chances := kenoChances(picks)
+	var weightsReturn float64
+	for hits, weight := range weights {
+		weightsReturn += weight * float64(chances[hits])
+	}
+	weightsReturn /= float64(combinations(uint64(KenoNumbers), uint64(KenoDrawn)))
+
+	result := make([]float64, len(weights))
+	for hits, weight := range weights {
+		coefficient := weight * (1 - l.edge) / weightsReturn
+		result[hits] = math.Floor(coefficient*100+1e-9) / 100
+	}
+	return result, nil
+}
+
+// KenoRTP returns the exact return to player of the payout table, computed in
+// hundredths over all C(40, 10) draws.
+func (l *Logic) KenoRTP(picks uint8, risk KenoRisk) (float64, error) {
+	coefficients, err := l.GenerateKenoCoefficients(picks, risk)
+	if err != nil {
+		return 0, err
+	}
+
+	chances := kenoChances(picks)
+	var total uint64
+	for hits, coefficient := range coefficients {
+		total += uint64(math.Round(coefficient*100)) * chances[hits]
+	}
+
+	draws := combinations(uint64(KenoNumbers), uint64(KenoDrawn))
+	return float64(total) / float64(100*draws), nil
+}
+
+func (l *Logic) GenerateKenoDraw(ctx context.Context) (*KenoDraw, error) {
+	startedAt := time.Now()
+	draw, err := l.generateKenoDraw()
+	l.observeGeneration("keno", draw, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSeeded(ctx, draw.ResultHash, draw); err != nil {
+		return nil, err
+	}
+	return draw, nil
+}
+
+func (l *Logic) generateKenoDraw() (*KenoDraw, error) {
+	base := make([]uint8, KenoNumbers)
+	for i := range base {
+		base[i] = uint8(i + 1)
+	}
+	numbers, err := l.allocate(base, int(KenoDrawn))
+	if err != nil {
+		return nil, err
+	}
+
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	join := joinUint8(numbers, "|")
+
+	result := fmt.Sprintf("%s|%s|%s", leftSeed, join, rightSeed)
+
+	draw := &KenoDraw{
+		Numbers:    numbers,
+		LeftSeed:   leftSeed,
+		RightSeed:  rightSeed,
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return draw, nil
+}
+
+func (l *Logic) KenoDrawFromString(result string) (*KenoDraw, error) {
+	elems := strings.Split(result, "|")
+	if len(elems) != int(KenoDrawn)+2 {
+		return nil, errors.New("wrong result string")
+	}
+
+	numbers := make([]uint8, KenoDrawn)
+	drawn := make(map[uint8]bool, KenoDrawn)
+	for i := range numbers {
+		number, err := strconv.ParseUint(elems[i+1], 10, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		if number < 1 || number > uint64(KenoNumbers) || drawn[uint8(number)] {
+			return nil, errors.New("wrong result string")
+		}
+
+		drawn[uint8(number)] = true
+		numbers[i] = uint8(number)
+	}
+
+	draw := &KenoDraw{
+		Numbers:    numbers,
+		LeftSeed:   elems[0],
+		RightSeed:  elems[KenoDrawn+1],
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return draw, nil
+}
+
+func (l *Logic) KenoHits(draw *KenoDraw, picks []uint8) (uint8, error) {
+	if len(picks) < 1 || len(picks) > int(KenoMaxPicks) {
+		return 0, errors.New("wrong picks count")
+	}
+
+	drawn := make(map[uint8]bool, len(draw.Numbers))
+	for _, number := range draw.Numbers {
+		drawn[number] = true
+	}
+
+	picked := make(map[uint8]bool, len(picks))
+	var hits uint8
+	for _, pick := range picks {
+		if pick < 1 || pick > KenoNumbers || picked[pick] {
+			return 0, errors.New("wrong picks")
+		}
+		picked[pick] = true
+
+		if drawn[pick] {
+			hits++
+		}
+	}
+	return hits, nil
+}
+
+func (l *Logic) SettleKeno(draw *KenoDraw, picks []uint8, risk KenoRisk) (float64, error) {
+	hits, err := l.KenoHits(draw, picks)
+	if err != nil {
+		return 0, err
+	}
+
+	coefficients, err := l.GenerateKenoCoefficients(uint8(len(picks)), risk)
+	if err != nil {
+		return 0, err
+	}
+
+	return coefficients[hits], nil
+}
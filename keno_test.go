@@ -0,0 +1,108 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestLogic_KenoRTP(t *testing.T) {
+	for _, edge := range []float64{0.01, DefaultHouseEdge} {
+		instance := New(os.Getenv("API_KEY"), WithHouseEdge(edge))
+		for _, risk := range []KenoRisk{KenoLow, KenoMedium, KenoHigh} {
+			for picks := uint8(1); picks <= KenoMaxPicks; picks++ {
+				rtp, err := instance.KenoRTP(picks, risk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rtp > 1-edge+1e-9 || rtp < 1-edge-0.01 {
+					t.Fatalf("unexpected rtp %v for %d picks, risk %d, edge %v", rtp, picks, risk, edge)
+				}
+			}
+		}
+	}
+}
+
+func TestLogic_GenerateKenoCoefficients(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	coefficients, err := instance.GenerateKenoCoefficients(1, KenoHigh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !float64Compare(coefficients, []float64{0, 3.8}) {
+		t.Fatalf("expected [0 3.8], but got %v", coefficients)
+	}
+
+	if _, err := instance.GenerateKenoCoefficients(11, KenoLow); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_GenerateKenoDraw(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	draw, err := instance.GenerateKenoDraw(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[uint8]bool)
+	for _, number := range draw.Numbers {
+		if number < 1 || number > KenoNumbers || seen[number] {
+			t.Fatalf("unexpected draw %v", draw.Numbers)
+		}
+		seen[number] = true
+	}
+
+	restored, err := instance.KenoDrawFromString(draw.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !uint8Compare(draw.Numbers, restored.Numbers) || draw.ResultHash != restored.ResultHash {
+		t.Fatalf("expected \"%v\", but got \"%v\"", draw.Numbers, restored.Numbers)
+	}
+}
+
+func TestLogic_SettleKeno(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	draw, err := instance.KenoDrawFromString("a|1|2|3|4|5|6|7|8|9|10|b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hits, err := instance.KenoHits(draw, []uint8{1, 5, 11, 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hits != 2 {
+		t.Fatalf("expected 2, but got %d", hits)
+	}
+
+	coefficient, err := instance.SettleKeno(draw, []uint8{10}, KenoHigh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coefficient != 3.8 {
+		t.Fatalf("expected 3.8, but got %v", coefficient)
+	}
+
+	if _, err := instance.KenoHits(draw, []uint8{1, 1}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.KenoHits(draw, []uint8{41}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_KenoDrawFromStringWrong(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	for _, result := range []string{
+		"a|0|0|0|0|0|0|0|0|0|0|b",
+		"a|1|2|3|4|5|6|7|8|9|41|b",
+		"a|1|2|3|4|5|6|7|8|9|9|b",
+		"a|1|2|3|4|5|6|7|8|9|b",
+	} {
+		if _, err := instance.KenoDrawFromString(result); err == nil {
+			t.Fatalf("expected error for \"%s\", but got nil", result)
+		}
+	}
+}
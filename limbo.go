@@ -0,0 +1,148 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type LimboResult struct {
+	Value       uint64
+	Coefficient float64
+	LeftSeed    string
+	RightSeed   string
+	Result      string
+	ResultHash  string
+}
+
+const LimboLength uint64 = 100000000
+
+const (
+	LimboMinTarget = 1.01
+	LimboMaxTarget = 1000000
+)
+
+// limboReturn is (1 - edge) * LimboLength expressed in hundredths.
+func (l *Logic) limboReturn() uint64 {
+	return uint64(math.Round((1 - l.edge) * float64(LimboLength) * 100))
+}
+
+// limboCoefficient is floor(limboReturn / (LimboLength - value)) / 100, so the
+// multiplier never rounds up in favour of the player.
+func (l *Logic) limboCoefficient(value uint64) float64 {
+	coefficient := float64(l.limboReturn()/(LimboLength-value)) / 100
+	if coefficient < 1 {
+		coefficient = 1
+	}
+	return coefficient
+}
+
+func limboTarget(target float64) (uint64, error) {
+	if target < LimboMinTarget || target > LimboMaxTarget {
+		return 0, errors.New("wrong target")
+	}
+
+	return uint64(math.Round(target * 100)), nil
+}
+
+func (l *Logic) GenerateLimboResult(ctx context.Context) (*LimboResult, error) {
+	startedAt := time.Now()
+	limbo, err := l.generateLimboResult()
+	l.observeGeneration("limbo", limbo, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSeeded(ctx, limbo.ResultHash, limbo); err != nil {
+		return nil, err
+	}
+	return limbo, nil
+}
+
+func (l *Logic) generateLimboResult() (*LimboResult, error) {
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := UniformInt(l.entropy, LimboLength)
+	if err != nil {
+		return nil, err
+	}
+
+	result := fmt.Sprintf("%s|%d|%s", leftSeed, value, rightSeed)
+
+	limbo := &LimboResult{
+		Value:       value,
+		Coefficient: l.limboCoefficient(value),
+		LeftSeed:    leftSeed,
+		RightSeed:   rightSeed,
+		Result:      result,
+		ResultHash:  hashResult(result),
+	}
+	return limbo, nil
+}
+
+func (l *Logic) LimboResultFromString(result string) (*LimboResult, error) {
+	elems := strings.Split(result, "|")
+	if len(elems) != 3 {
+		return nil, errors.New("wrong result string")
+	}
+
+	value, err := strconv.ParseUint(elems[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if value >= LimboLength {
+		return nil, errors.New("wrong result string")
+	}
+
+	limbo := &LimboResult{
+		Value:       value,
+		Coefficient: l.limboCoefficient(value),
+		LeftSeed:    elems[0],
+		RightSeed:   elems[2],
+		Result:      result,
+		ResultHash:  hashResult(result),
+	}
+	return limbo, nil
+}
+
+// LimboChanceByTarget returns the exact probability that the generated
+// multiplier reaches target: floor(limboReturn / target) out of LimboLength
+// values win.
+func (l *Logic) LimboChanceByTarget(target float64) (float64, error) {
+	cents, err := limboTarget(target)
+	if err != nil {
+		return 0, err
+	}
+
+	wins := l.limboReturn() / cents
+	if wins > LimboLength {
+		wins = LimboLength
+	}
+
+	return float64(wins) / float64(LimboLength), nil
+}
+
+func (l *Logic) SettleLimbo(target float64, limbo *LimboResult) (float64, error) {
+	cents, err := limboTarget(target)
+	if err != nil {
+		return 0, err
+	}
+
+	if limbo.Value >= LimboLength {
+		return 0, errors.New("wrong limbo result")
+	}
+
+	if l.limboReturn()/(LimboLength-limbo.Value) < cents {
+		return 0, nil
+	}
+
+	return float64(cents) / 100, nil
+}
@@ -0,0 +1,76 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestLogic_GenerateLimboResult(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	limbo, err := instance.GenerateLimboResult(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := instance.LimboResultFromString(limbo.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Value != limbo.Value || restored.Coefficient != limbo.Coefficient {
+		t.Fatalf("expected %d (%v), but got %d (%v)", limbo.Value, limbo.Coefficient, restored.Value, restored.Coefficient)
+	}
+	if restored.ResultHash != limbo.ResultHash {
+		t.Fatalf("expected \"%s\", but got \"%s\"", limbo.ResultHash, restored.ResultHash)
+	}
+}
+
+func TestLogic_LimboChanceByTarget(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	chance, err := instance.LimboChanceByTarget(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chance != 0.475 {
+		t.Fatalf("expected 0.475, but got %v", chance)
+	}
+
+	if _, err := instance.LimboChanceByTarget(1); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_SettleLimbo(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	win, err := instance.LimboResultFromString("a|52500000|b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if win.Coefficient != 2 {
+		t.Fatalf("expected 2, but got %v", win.Coefficient)
+	}
+	loss, err := instance.LimboResultFromString("a|52499999|b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loss.Coefficient != 1.99 {
+		t.Fatalf("expected 1.99, but got %v", loss.Coefficient)
+	}
+
+	if coefficient, _ := instance.SettleLimbo(2, win); coefficient != 2 {
+		t.Fatalf("expected 2, but got %v", coefficient)
+	}
+	if coefficient, _ := instance.SettleLimbo(2, loss); coefficient != 0 {
+		t.Fatalf("expected 0, but got %v", coefficient)
+	}
+}
+
+func TestLogic_LimboResultFromStringWrong(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	if _, err := instance.LimboResultFromString("a|100000000|b"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.LimboResultFromString("a|1"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
@@ -0,0 +1,142 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Logger takes a message and key/value pairs, as *slog.Logger does.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+const redacted = "[REDACTED]"
+
+var (
+	seedFieldPattern = regexp.MustCompile(`(?i)("?(?:left|right)_?seed"?\s*[:=]\s*"?)[0-9a-z]+`)
+	resultPattern    = regexp.MustCompile(`[0-9a-z]+(?:\|[0-9]+)+\|[0-9a-z]+`)
+	seedPattern      = regexp.MustCompile(`\b[0-9a-z]{36}\b`)
+)
+
+// redactingLogger removes the API key and seeds from the message and the
+// values before passing them on. Strings, byte slices and errors are
+// redacted as text. Any other value is formatted and, if that reveals
+// something, passed on as redacted text instead.
+type redactingLogger struct {
+	next Logger
+	key  string
+}
+
+func WithLogger(logger Logger) Option {
+	return func(l *Logic) {
+		if logger == nil {
+			l.logger = nil
+			l.api.logger = nil
+			return
+		}
+
+		redacting := &redactingLogger{next: logger, key: l.api.key}
+		l.logger = redacting
+		l.api.logger = redacting
+	}
+}
+
+func (r *redactingLogger) text(text string) string {
+	if r.key != "" {
+		text = strings.ReplaceAll(text, r.key, redacted)
+	}
+	text = seedFieldPattern.ReplaceAllString(text, "${1}"+redacted)
+	text = resultPattern.ReplaceAllString(text, redacted)
+	return seedPattern.ReplaceAllString(text, redacted)
+}
+
+func (r *redactingLogger) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return r.text(v)
+	case json.RawMessage:
+		return r.text(string(v))
+	case []byte:
+		return r.text(string(v))
+	case error:
+		return r.text(v.Error())
+	case bool, int, int64, uint8, uint64, float64, time.Duration, time.Time:
+		return v
+	}
+
+	formatted := fmt.Sprintf("%+v", value)
+	if text := r.text(formatted); text != formatted {
+		return text
+	}
+	return value
+}
+
+func (r *redactingLogger) args(args []interface{}) []interface{} {
+	redactedArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		redactedArgs[i] = r.value(arg)
+	}
+	return redactedArgs
+}
+
+func (r *redactingLogger) Debug(msg string, args ...interface{}) {
+	r.next.Debug(r.text(msg), r.args(args)...)
+}
+
+func (r *redactingLogger) Info(msg string, args ...interface{}) {
+	r.next.Info(r.text(msg), r.args(args)...)
+}
+
+func (r *redactingLogger) Warn(msg string, args ...interface{}) {
+	r.next.Warn(r.text(msg), r.args(args)...)
+}
+
+func (r *redactingLogger) Error(msg string, args ...interface{}) {
+	r.next.Error(r.text(msg), r.args(args)...)
+}
+
+// generationID returns the log field that tells a result apart without
+// revealing it before the round ends: the result hash of seeded games and the
+// serial number of signed games.
+func generationID(result interface{}) (string, interface{}) {
+	switch r := result.(type) {
+	case *MinesAllocation:
+		return "resultHash", r.ResultHash
+	case *DiceNumber:
+		return "resultHash", r.ResultHash
+	case *LimboResult:
+		return "resultHash", r.ResultHash
+	case *PlinkoPath:
+		return "resultHash", r.ResultHash
+	case *KenoDraw:
+		return "resultHash", r.ResultHash
+	case *CoinflipFlips:
+		return "resultHash", r.ResultHash
+	case *CardDeck:
+		return "resultHash", r.ResultHash
+	case *TowerAllocation:
+		return "resultHash", r.ResultHash
+	case *SlotSpin:
+		return "resultHash", r.ResultHash
+	case *CaseOpening:
+		return "resultHash", r.ResultHash
+	case *CrashCoefficient:
+		return "serialNumber", r.SerialNumber
+	case *DoubleNumber:
+		return "serialNumber", r.SerialNumber
+	case *RouletteNumber:
+		return "serialNumber", r.SerialNumber
+	case *JackpotDraw:
+		return "serialNumber", r.SerialNumber
+	default:
+		return "result", "unknown"
+	}
+}
@@ -0,0 +1,186 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+)
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	records []string
+}
+
+func (r *recordingLogger) record(level string, msg string, args []interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, fmt.Sprintf("%s %s %v", level, msg, args))
+}
+
+func (r *recordingLogger) Debug(msg string, args ...interface{}) {
+	r.record("DEBUG", msg, args)
+}
+
+func (r *recordingLogger) Info(msg string, args ...interface{}) {
+	r.record("INFO", msg, args)
+}
+
+func (r *recordingLogger) Warn(msg string, args ...interface{}) {
+	r.record("WARN", msg, args)
+}
+
+func (r *recordingLogger) Error(msg string, args ...interface{}) {
+	r.record("ERROR", msg, args)
+}
+
+func (r *recordingLogger) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return strings.Join(r.records, "\n")
+}
+
+func TestWithLogger_Api(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":{"random":{"method":"generateSignedDecimalFractions","n":1,"decimalPlaces":3,"data":[0.5],"serialNumber":42},` +
+			`"signature":"signature","bitsUsed":10,"bitsLeft":249990,"requestsLeft":999},"id":1337}`))
+	}))
+	url := ApiUrl
+	ApiUrl = server.URL
+	defer func() {
+		ApiUrl = url
+		server.Close()
+	}()
+
+	key := "00000000-aaaa-bbbb-cccc-secretapikey"
+	logger := &recordingLogger{}
+	instance := New(key, WithLogger(logger))
+
+	if _, err := instance.GenerateCrashCoefficient(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	records := logger.String()
+	for _, secret := range []string{key, "0.5", "signature"} {
+		if strings.Contains(records, secret) {
+			t.Fatalf("expected no \"%s\", but got\n%s", secret, records)
+		}
+	}
+	for _, part := range []string{"DEBUG random.org request", "DEBUG random.org response", "INFO result generated", "serialNumber 42", "bitsLeft 249990", "requestsLeft 999"} {
+		if !strings.Contains(records, part) {
+			t.Fatalf("expected \"%s\" in\n%s", part, records)
+		}
+	}
+}
+
+func TestWithLogger_ApiError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	url := ApiUrl
+	ApiUrl = server.URL
+	defer func() {
+		ApiUrl = url
+	}()
+
+	logger := &recordingLogger{}
+	instance := New("secret-api-key", WithLogger(logger), WithApiRetries(1))
+
+	if _, err := instance.GenerateCrashCoefficient(context.Background()); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+
+	records := logger.String()
+	for _, part := range []string{"WARN random.org request retry", "ERROR random.org request failed", "ERROR result generation failed"} {
+		if !strings.Contains(records, part) {
+			t.Fatalf("expected \"%s\" in\n%s", part, records)
+		}
+	}
+}
+
+func TestWithLogger_Seeds(t *testing.T) {
+	logger := &recordingLogger{}
+	instance := New("", WithLogger(logger))
+
+	number, err := instance.GenerateDiceNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	allocation, err := instance.GenerateMinesAllocation()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := logger.String()
+	for _, secret := range []string{number.LeftSeed, number.RightSeed, number.Result, allocation.LeftSeed, allocation.RightSeed, allocation.Result} {
+		if strings.Contains(records, secret) {
+			t.Fatalf("expected no \"%s\", but got\n%s", secret, records)
+		}
+	}
+	for _, public := range []string{number.ResultHash, allocation.ResultHash} {
+		if !strings.Contains(records, public) {
+			t.Fatalf("expected \"%s\" in\n%s", public, records)
+		}
+	}
+}
+
+func TestWithLogger_Outcomes(t *testing.T) {
+	logger := &recordingLogger{}
+	instance := New("", WithLogger(logger))
+
+	allocation, err := instance.GenerateMinesAllocation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	number, err := instance.GenerateDiceNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := logger.String()
+	if places := fmt.Sprint(allocation.Places); strings.Contains(records, places) {
+		t.Fatalf("expected no places \"%s\", but got\n%s", places, records)
+	}
+
+	value := strconv.FormatUint(number.Value, 10)
+	tokens := strings.FieldsFunc(records, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, token := range tokens {
+		if token == value {
+			t.Fatalf("expected no value \"%s\", but got\n%s", value, records)
+		}
+	}
+}
+
+func TestLogic_generationID(t *testing.T) {
+	results := []interface{}{
+		&CrashCoefficient{}, &DoubleNumber{}, &RouletteNumber{}, &JackpotDraw{},
+		&MinesAllocation{}, &DiceNumber{}, &LimboResult{}, &PlinkoPath{}, &KenoDraw{},
+		&CoinflipFlips{}, &CardDeck{}, &TowerAllocation{}, &SlotSpin{}, &CaseOpening{},
+	}
+	for _, result := range results {
+		if key, _ := generationID(result); key != "resultHash" && key != "serialNumber" {
+			t.Fatalf("expected an id for %T, but got %s", result, key)
+		}
+	}
+}
+
+func TestRedactingLogger_Values(t *testing.T) {
+	logger := &recordingLogger{}
+	redacting := &redactingLogger{next: logger, key: "secret-api-key"}
+
+	redacting.Error("failed with secret-api-key", "count", 3, "error", errors.New("wrong key secret-api-key"), "body", []byte(`{"leftSeed":"abc123"}`))
+
+	expected := "ERROR failed with [REDACTED] [count 3 error wrong key [REDACTED] body {\"leftSeed\":\"[REDACTED]\"}]"
+	if records := logger.String(); records != expected {
+		t.Fatalf("expected %s, but got %s", expected, records)
+	}
+}
@@ -2,19 +2,51 @@ package logic
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha512"
 	"errors"
 	"fmt"
+	"io"
 	"math"
-	"math/rand"
 	"strconv"
 	"strings"
 	"time"
 )
 
 type Logic struct {
-	api  *Api
-	rand *rand.Rand
+	api     *Api
+	entropy io.Reader
+	edge    float64
+	store   RoundStore
+	audit   *auditLog
+	games   *GameRegistry
+	metrics Metrics
+	logger  Logger
+
+	signatureKey *rsa.PublicKey
+}
+
+type Option func(l *Logic)
+
+const DefaultHouseEdge = 0.05
+
+func WithEntropy(entropy io.Reader) Option {
+	return func(l *Logic) {
+		l.entropy = entropy
+	}
+}
+
+// WithHouseEdge sets the edge of the games. An edge outside of [0, 1) would
+// give negative or over 100% coefficients, so it is ignored and
+// DefaultHouseEdge is kept.
+func WithHouseEdge(edge float64) Option {
+	return func(l *Logic) {
+		if !(edge >= 0 && edge < 1) {
+			return
+		}
+		l.edge = edge
+	}
 }
 
 type CrashCoefficient struct {
@@ -48,6 +80,16 @@ type MinesAllocation struct {
 var letters = []rune("1234567890abcdefghijklmnopqrstuvwxyz")
 var lettersLength = len(letters)
 
+type MinesGame struct {
+	Allocation  *MinesAllocation
+	Mines       []uint8
+	Opened      []uint8
+	Exploded    uint8
+	Won         bool
+	Coefficient float64
+	Payout      float64
+}
+
 type DiceNumber struct {
 	Value      uint64
 	LeftSeed   string
@@ -68,6 +110,31 @@ func crashFloor(value float64) float64 {
 	return result
 }
 
+func hashResult(result string) string {
+	hash := sha512.New()
+	hash.Write([]byte(result))
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+func (l *Logic) generateSeeds() (string, string, error) {
+	leftSeed := make([]rune, lettersLength)
+	rightSeed := make([]rune, lettersLength)
+	for i := range leftSeed {
+		left, err := UniformInt(l.entropy, uint64(lettersLength))
+		if err != nil {
+			return "", "", err
+		}
+		right, err := UniformInt(l.entropy, uint64(lettersLength))
+		if err != nil {
+			return "", "", err
+		}
+
+		leftSeed[i] = letters[left]
+		rightSeed[i] = letters[right]
+	}
+	return string(leftSeed), string(rightSeed), nil
+}
+
 func joinUint8(elems []uint8, sep string) string {
 	var builder strings.Builder
 
@@ -83,6 +150,23 @@ func joinUint8(elems []uint8, sep string) string {
 }
 
 func (l *Logic) GenerateCrashCoefficient(ctx context.Context) (*CrashCoefficient, error) {
+	startedAt := time.Now()
+	coef, err := l.generateCrashCoefficient(ctx)
+	l.observeGeneration("crash", coef, err, startedAt)
+	if err := l.auditCall("GenerateCrashCoefficient", "random.org", map[string]int{"decimalPlaces": 3}, coef, err, startedAt); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSigned(ctx, coef.Random, coef.SerialNumber, coef); err != nil {
+		return nil, err
+	}
+	return coef, nil
+}
+
+func (l *Logic) generateCrashCoefficient(ctx context.Context) (*CrashCoefficient, error) {
 	decimal, err := l.api.GenerateDecimal(ctx, 3)
 	if err != nil {
 		return nil, fmt.Errorf("random.org api error: %v", err)
@@ -106,6 +190,23 @@ func (l *Logic) CrashDurationByCoefficient(coefficient float64) float64 {
 }
 
 func (l *Logic) GenerateDoubleNumber(ctx context.Context) (*DoubleNumber, error) {
+	startedAt := time.Now()
+	number, err := l.generateDoubleNumber(ctx)
+	l.observeGeneration("double", number, err, startedAt)
+	if err := l.auditCall("GenerateDoubleNumber", "random.org", map[string]int{"min": 0, "max": 53}, number, err, startedAt); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSigned(ctx, number.Random, number.SerialNumber, number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+func (l *Logic) generateDoubleNumber(ctx context.Context) (*DoubleNumber, error) {
 	integer, err := l.api.GenerateInteger(ctx, 0, 53)
 	if err != nil {
 		return nil, fmt.Errorf("random.org api error: %v", err)
@@ -117,7 +218,6 @@ func (l *Logic) GenerateDoubleNumber(ctx context.Context) (*DoubleNumber, error)
 		Signature:    integer.Signature,
 		SerialNumber: integer.SerialNumber,
 	}
-
 	return number, nil
 }
 
@@ -129,64 +229,116 @@ func (l *Logic) DoubleCoefficientByNumber(number uint8) uint8 {
 	return doubleCoefficients[number]
 }
 
+// survivalCoefficients returns the coefficient for every step of a game where
+// step i is survived with probability chances[i] once the previous steps were.
+func (l *Logic) survivalCoefficients(chances []float64) []float64 {
+	result := make([]float64, len(chances))
+
+	var prevChance float64 = 1
+	for i, stepChance := range chances {
+		chance := stepChance * prevChance
+		coefficient := 1 / chance
+
+		result[i] = math.Round(coefficient*(1-l.edge)*100) / 100
+
+		prevChance = chance
+	}
+	return result
+}
+
 func (l *Logic) GenerateMinesCoefficients(mines uint8) ([]float64, error) {
 	if mines < 2 || mines > 24 {
 		return nil, errors.New("wrong mines count")
 	}
 
-	result := make([]float64, 25-mines)
+	chances := make([]float64, 25-mines)
 
 	var step uint8
-	var prevChance float64 = 1
 	for step = 1; step <= 25-mines; step++ {
 		freeClear := float64(25 - mines - step + 1)
 		freeTotal := float64(25 - step + 1)
 
-		chance := freeClear / freeTotal * prevChance
-		coefficient := 1 / chance
-
-		result[step-1] = math.Round(coefficient*95) / 100
+		chances[step-1] = freeClear / freeTotal
+	}
+	return l.survivalCoefficients(chances), nil
+}
 
-		prevChance = chance
+// allocate draws count elements of base without replacement, taking a random
+// element and moving the last one into its place.
+func (l *Logic) allocate(base []uint8, count int) ([]uint8, error) {
+	places := make([]uint8, count)
+	for i := 0; i < count; i++ {
+		baseLength := len(base)
+		r, err := UniformInt(l.entropy, uint64(baseLength))
+		if err != nil {
+			return nil, err
+		}
+		places[i] = base[r]
+		base[r] = base[baseLength-1]
+		base = base[:baseLength-1]
 	}
-	return result, nil
+	return places, nil
 }
 
 func (l *Logic) GenerateMinesAllocation() (*MinesAllocation, error) {
+	return l.GenerateMinesAllocationContext(context.Background())
+}
+
+// GenerateMinesAllocationContext is GenerateMinesAllocation with the context
+// passed to the round store.
+func (l *Logic) GenerateMinesAllocationContext(ctx context.Context) (*MinesAllocation, error) {
+	allocation, err := l.unrecordedMinesAllocation()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSeeded(ctx, allocation.ResultHash, allocation); err != nil {
+		return nil, err
+	}
+	return allocation, nil
+}
+
+// unrecordedMinesAllocation generates an allocation without saving it into
+// the round store, for the commitment pool to record it once handed out.
+func (l *Logic) unrecordedMinesAllocation() (*MinesAllocation, error) {
+	startedAt := time.Now()
+	allocation, err := l.generateMinesAllocation()
+	l.observeGeneration("mines", allocation, err, startedAt)
+	if err := l.auditCall("GenerateMinesAllocation", "entropy", nil, allocation, err, startedAt); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return allocation, nil
+}
+
+func (l *Logic) generateMinesAllocation() (*MinesAllocation, error) {
 	base := []uint8{
 		1, 2, 3, 4, 5, 6, 7, 8, 9, 10,
 		11, 12, 13, 14, 15, 16, 17, 18,
 		19, 20, 21, 22, 23, 24, 25,
 	}
-	places := make([]uint8, 25)
-	for i := 0; i < 25; i++ {
-		baseLength := len(base)
-		r := rand.Intn(baseLength)
-		places[i] = base[r]
-		base[r] = base[baseLength-1]
-		base = base[:baseLength-1]
+	places, err := l.allocate(base, 25)
+	if err != nil {
+		return nil, err
 	}
 
-	leftSeed := make([]rune, lettersLength)
-	rightSeed := make([]rune, lettersLength)
-	for i := range leftSeed {
-		leftSeed[i] = letters[rand.Intn(lettersLength)]
-		rightSeed[i] = letters[rand.Intn(lettersLength)]
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
 	}
 
 	join := joinUint8(places, "|")
 
-	result := fmt.Sprintf("%s|%s|%s", string(leftSeed), join, string(rightSeed))
-	hash := sha512.New()
-	hash.Write([]byte(result))
-	resultHash := fmt.Sprintf("%x", hash.Sum(nil))
+	result := fmt.Sprintf("%s|%s|%s", leftSeed, join, rightSeed)
 
 	allocation := &MinesAllocation{
 		Places:     places,
-		LeftSeed:   string(leftSeed),
-		RightSeed:  string(rightSeed),
+		LeftSeed:   leftSeed,
+		RightSeed:  rightSeed,
 		Result:     result,
-		ResultHash: resultHash,
+		ResultHash: hashResult(result),
 	}
 	return allocation, nil
 }
@@ -198,48 +350,144 @@ func (l *Logic) MinesAllocationFromString(result string) (*MinesAllocation, erro
 	}
 
 	places := make([]uint8, 25)
+	seen := make(map[uint8]bool, 25)
 	for i := 0; i < 25; i++ {
 		place, err := strconv.ParseUint(elems[i+1], 10, 8)
 		if err != nil {
 			return nil, err
 		}
 
+		if place < 1 || place > 25 || seen[uint8(place)] {
+			return nil, errors.New("wrong result string")
+		}
+
+		seen[uint8(place)] = true
 		places[i] = uint8(place)
 	}
 
-	hash := sha512.New()
-	hash.Write([]byte(result))
-
 	allocation := &MinesAllocation{
 		Places:     places,
 		LeftSeed:   elems[0],
 		RightSeed:  elems[26],
 		Result:     result,
-		ResultHash: fmt.Sprintf("%x", hash.Sum(nil)),
+		ResultHash: hashResult(result),
 	}
 	return allocation, nil
 }
 
+// VerifyMinesGame replays a finished game from its revealed result and
+// returns the outcome with the payout of a bet of amount.
+func (l *Logic) VerifyMinesGame(result string, resultHash string, mines uint8, reveals []uint8, amount float64) (*MinesGame, error) {
+	allocation, err := l.VerifyMinesAllocation(result, resultHash)
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := l.playMinesGame(allocation, mines, reveals)
+	if err != nil {
+		return nil, err
+	}
+
+	game.Payout = amount * game.Coefficient
+	return game, nil
+}
+
+func (l *Logic) playMinesGame(allocation *MinesAllocation, mines uint8, reveals []uint8) (*MinesGame, error) {
+	coefficients, err := l.GenerateMinesCoefficients(mines)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reveals) == 0 {
+		return nil, errors.New("wrong reveal sequence")
+	}
+
+	isMine := make(map[uint8]bool, mines)
+	for _, place := range allocation.Places[:mines] {
+		isMine[place] = true
+	}
+
+	game := &MinesGame{
+		Allocation: allocation,
+		Mines:      allocation.Places[:mines],
+		Opened:     make([]uint8, 0, len(reveals)),
+	}
+
+	opened := make(map[uint8]bool, len(reveals))
+	for i, cell := range reveals {
+		if cell < 1 || cell > 25 || opened[cell] {
+			return nil, errors.New("wrong reveal sequence")
+		}
+
+		if isMine[cell] {
+			if i+1 < len(reveals) {
+				return nil, errors.New("wrong reveal sequence")
+			}
+			game.Exploded = cell
+			return game, nil
+		}
+
+		opened[cell] = true
+		game.Opened = append(game.Opened, cell)
+	}
+
+	game.Won = true
+	game.Coefficient = coefficients[len(game.Opened)-1]
+	return game, nil
+}
+
 func (l *Logic) GenerateDiceNumber() (*DiceNumber, error) {
-	leftSeed := make([]rune, lettersLength)
-	rightSeed := make([]rune, lettersLength)
-	for i := range leftSeed {
-		leftSeed[i] = letters[rand.Intn(lettersLength)]
-		rightSeed[i] = letters[rand.Intn(lettersLength)]
+	return l.GenerateDiceNumberContext(context.Background())
+}
+
+// GenerateDiceNumberContext is GenerateDiceNumber with the context passed to
+// the round store.
+func (l *Logic) GenerateDiceNumberContext(ctx context.Context) (*DiceNumber, error) {
+	number, err := l.unrecordedDiceNumber()
+	if err != nil {
+		return nil, err
 	}
 
-	value := l.rand.Intn(1000000)
-	result := fmt.Sprintf("%s|%d|%s", string(leftSeed), value, string(rightSeed))
-	hash := sha512.New()
-	hash.Write([]byte(result))
-	resultHash := fmt.Sprintf("%x", hash.Sum(nil))
+	if err := l.recordSeeded(ctx, number.ResultHash, number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+// unrecordedDiceNumber generates a number without saving it into the round
+// store, for the commitment pool to record it once handed out.
+func (l *Logic) unrecordedDiceNumber() (*DiceNumber, error) {
+	startedAt := time.Now()
+	number, err := l.generateDiceNumber()
+	l.observeGeneration("dice", number, err, startedAt)
+	if err := l.auditCall("GenerateDiceNumber", "entropy", nil, number, err, startedAt); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+func (l *Logic) generateDiceNumber() (*DiceNumber, error) {
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := UniformInt(l.entropy, DiceLength)
+	if err != nil {
+		return nil, err
+	}
+
+	result := fmt.Sprintf("%s|%d|%s", leftSeed, value, rightSeed)
 
 	number := &DiceNumber{
-		Value:      uint64(value),
-		LeftSeed:   string(leftSeed),
-		RightSeed:  string(rightSeed),
+		Value:      value,
+		LeftSeed:   leftSeed,
+		RightSeed:  rightSeed,
 		Result:     result,
-		ResultHash: resultHash,
+		ResultHash: hashResult(result),
 	}
 	return number, nil
 }
@@ -249,7 +497,7 @@ func (l *Logic) DiceCoefficientByChance(chance uint8) (float64, error) {
 		return 0, errors.New("wrong chance")
 	}
 
-	coefficient := 1.0 / (float64(chance) / 100) * 0.95
+	coefficient := 1.0 / (float64(chance) / 100) * (1 - l.edge)
 	return coefficient, nil
 }
 
@@ -272,23 +520,26 @@ func (l *Logic) DiceNumberFromString(result string) (*DiceNumber, error) {
 		return nil, err
 	}
 
-	hash := sha512.New()
-	hash.Write([]byte(result))
-	resultHash := fmt.Sprintf("%x", hash.Sum(nil))
-
 	number := &DiceNumber{
 		Value:      value,
 		LeftSeed:   elems[0],
 		RightSeed:  elems[2],
 		Result:     result,
-		ResultHash: resultHash,
+		ResultHash: hashResult(result),
 	}
 	return number, nil
 }
 
-func New(apiKey string) *Logic {
-	return &Logic{
-		api:  NewApi(apiKey),
-		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+func New(apiKey string, options ...Option) *Logic {
+	l := &Logic{
+		api:     NewApi(apiKey),
+		entropy: rand.Reader,
+		edge:    DefaultHouseEdge,
+		games:   NewGameRegistry(),
+	}
+	for _, option := range options {
+		option(l)
 	}
+	l.registerGames()
+	return l
 }
@@ -134,6 +134,19 @@ func TestLogic_MinesAllocationFromString(t *testing.T) {
 	}
 }
 
+func TestLogic_MinesAllocationFromStringWrongPlaces(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	for _, result := range []string{
+		"s828mk09wr|6|6|13|23|4|16|14|5|9|12|22|21|8|24|25|7|18|10|1|20|17|15|11|3|2|xbfb1t3bgj",
+		"s828mk09wr|6|19|13|23|4|16|14|5|9|12|22|21|8|24|25|7|18|10|1|20|17|15|11|3|26|xbfb1t3bgj",
+		"s828mk09wr|6|19|13|23|4|16|14|5|9|12|22|21|8|24|25|7|18|10|1|20|17|15|11|3|0|xbfb1t3bgj",
+	} {
+		if _, err := instance.MinesAllocationFromString(result); err == nil {
+			t.Fatalf("expected error for \"%s\", but got nil", result)
+		}
+	}
+}
+
 func TestLogic_GenerateDiceNumber(t *testing.T) {
 	instance := New(os.Getenv("API_KEY"))
 	number, err := instance.GenerateDiceNumber()
@@ -194,3 +207,50 @@ func TestLogic_DiceLengthByChance(t *testing.T) {
 		t.Fatalf("expected 30000, but got %d", length)
 	}
 }
+
+func TestLogic_VerifyMinesGame(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	result := "s828mk09wr|6|19|13|23|4|16|14|5|9|12|22|21|8|24|25|7|18|10|1|20|17|15|11|3|2|xbfb1t3bgj"
+	resultHash := "8c9bf431e0cbcb1d77dd23e8f03ef01237dbd0f77b166279752a1fb110432d760e7d82affef11dcf65a489f126b2ed7f8b2b768ddbaac3aaa4f0544ea9dad53f"
+
+	game, err := instance.VerifyMinesGame(result, resultHash, 2, []uint8{1, 2, 3}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !game.Won || game.Coefficient != 1.23 || game.Payout != 12.3 {
+		t.Fatalf("expected win of 12.3 with 1.23, but got %v of %v with %v", game.Won, game.Payout, game.Coefficient)
+	}
+	if !uint8Compare(game.Mines, []uint8{6, 19}) {
+		t.Fatalf("expected \"[6 19]\", but got \"%v\"", game.Mines)
+	}
+
+	game, err = instance.VerifyMinesGame(result, resultHash, 2, []uint8{1, 19}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Won || game.Payout != 0 || game.Exploded != 19 {
+		t.Fatalf("expected loss on 19, but got %v on %d", game.Won, game.Exploded)
+	}
+}
+
+func TestLogic_VerifyMinesGameWrongInput(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	result := "s828mk09wr|6|19|13|23|4|16|14|5|9|12|22|21|8|24|25|7|18|10|1|20|17|15|11|3|2|xbfb1t3bgj"
+	resultHash := "8c9bf431e0cbcb1d77dd23e8f03ef01237dbd0f77b166279752a1fb110432d760e7d82affef11dcf65a489f126b2ed7f8b2b768ddbaac3aaa4f0544ea9dad53f"
+
+	if _, err := instance.VerifyMinesGame(result, "wrong", 2, []uint8{1}, 10); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyMinesGame(result, resultHash, 2, []uint8{1, 1}, 10); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyMinesGame(result, resultHash, 2, []uint8{6, 1}, 10); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyMinesGame(result, resultHash, 2, []uint8{26}, 10); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyMinesGame(result, resultHash, 25, []uint8{1}, 10); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
@@ -0,0 +1,195 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MerkleCommitment is the Merkle tree over the rounds of a day. Leaves are the
+// round IDs, i.e. the ResultHash of seeded rounds and the SHA-512 of the
+// random object of signed rounds, in order of creation.
+//
+// A leaf hashes as SHA512(0x00 || id) and a node as SHA512(0x01 || left ||
+// right). A node without a pair is carried to the next level as is, and the
+// root of an empty day is SHA512 of nothing.
+type MerkleCommitment struct {
+	Day    string   `json:"day"`
+	Root   string   `json:"root"`
+	Leaves []string `json:"leaves"`
+}
+
+type MerkleStep struct {
+	Hash string `json:"hash"`
+	Left bool   `json:"left"`
+}
+
+type MerkleProof struct {
+	Leaf  string       `json:"leaf"`
+	Index int          `json:"index"`
+	Path  []MerkleStep `json:"path"`
+}
+
+func merkleLeaf(leaf string) []byte {
+	hash := sha512.Sum512(append([]byte{0}, leaf...))
+	return hash[:]
+}
+
+func merkleNode(left []byte, right []byte) []byte {
+	data := make([]byte, 0, 1+len(left)+len(right))
+	data = append(data, 1)
+	data = append(data, left...)
+	data = append(data, right...)
+	hash := sha512.Sum512(data)
+	return hash[:]
+}
+
+// merkleLevels returns every level of the tree from the leaves to the root.
+func merkleLevels(leaves []string) [][][]byte {
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = merkleLeaf(leaf)
+	}
+
+	levels := [][][]byte{level}
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, merkleNode(level[i], level[i+1]))
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return levels
+}
+
+func merkleRoot(leaves []string) string {
+	if len(leaves) == 0 {
+		hash := sha512.Sum512(nil)
+		return hex.EncodeToString(hash[:])
+	}
+
+	levels := merkleLevels(leaves)
+	return hex.EncodeToString(levels[len(levels)-1][0])
+}
+
+func (l *Logic) BuildMerkleCommitment(day time.Time, leaves []string) *MerkleCommitment {
+	commitment := &MerkleCommitment{
+		Day:    day.UTC().Format("2006-01-02"),
+		Root:   merkleRoot(leaves),
+		Leaves: append([]string(nil), leaves...),
+	}
+	return commitment
+}
+
+// merkleCommitDelay is how long after midnight UTC RunDailyCommitments waits
+// before committing the day, so rounds created just before midnight have
+// been saved.
+const merkleCommitDelay = time.Minute
+
+// CommitRoundsDay builds the commitment over every round of the round store
+// created on the UTC day of day. The day must be over, otherwise rounds
+// created later would be left out of the commitment.
+func (l *Logic) CommitRoundsDay(ctx context.Context, day time.Time) (*MerkleCommitment, error) {
+	if l.store == nil {
+		return nil, errors.New("no round store")
+	}
+
+	from := day.UTC().Truncate(24 * time.Hour)
+	if time.Now().Before(from.Add(24 * time.Hour)) {
+		return nil, errors.New("day is not over")
+	}
+
+	rounds, err := l.store.List(ctx, from, from.Add(24*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("round store error: %v", err)
+	}
+
+	leaves := make([]string, len(rounds))
+	for i, round := range rounds {
+		leaves[i] = round.ID
+	}
+	return l.BuildMerkleCommitment(from, leaves), nil
+}
+
+// RunDailyCommitments commits every UTC day starting with the day of since
+// once it is over and passes the commitment to publish, e.g. to put the root
+// on a public page. It runs until ctx is done or publish fails.
+func (l *Logic) RunDailyCommitments(ctx context.Context, since time.Time, publish func(ctx context.Context, commitment *MerkleCommitment) error) error {
+	day := since.UTC().Truncate(24 * time.Hour)
+	for {
+		if err := sleep(ctx, time.Until(day.Add(24*time.Hour+merkleCommitDelay))); err != nil {
+			return err
+		}
+
+		commitment, err := l.CommitRoundsDay(ctx, day)
+		if err != nil {
+			return err
+		}
+		if err := publish(ctx, commitment); err != nil {
+			return fmt.Errorf("publish error: %v", err)
+		}
+		day = day.Add(24 * time.Hour)
+	}
+}
+
+func (l *Logic) GenerateMerkleProof(commitment *MerkleCommitment, leaf string) (*MerkleProof, error) {
+	index := -1
+	for i, current := range commitment.Leaves {
+		if current == leaf {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, errors.New("wrong leaf")
+	}
+
+	proof := &MerkleProof{
+		Leaf:  leaf,
+		Index: index,
+	}
+
+	levels := merkleLevels(commitment.Leaves)
+	position := index
+	for _, level := range levels[:len(levels)-1] {
+		sibling := position ^ 1
+		if sibling < len(level) {
+			proof.Path = append(proof.Path, MerkleStep{
+				Hash: hex.EncodeToString(level[sibling]),
+				Left: sibling < position,
+			})
+		}
+		position /= 2
+	}
+	return proof, nil
+}
+
+func (l *Logic) VerifyMerkleProof(root string, proof *MerkleProof) error {
+	hash := merkleLeaf(proof.Leaf)
+	for _, step := range proof.Path {
+		sibling, err := hex.DecodeString(step.Hash)
+		if err != nil {
+			return err
+		}
+
+		if step.Left {
+			hash = merkleNode(sibling, hash)
+		} else {
+			hash = merkleNode(hash, sibling)
+		}
+	}
+
+	if hex.EncodeToString(hash) != strings.ToLower(root) {
+		return errors.New("wrong merkle proof")
+	}
+	return nil
+}
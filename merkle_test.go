@@ -0,0 +1,149 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLogic_GenerateMerkleProof(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	for count := 1; count <= 9; count++ {
+		leaves := make([]string, count)
+		for i := range leaves {
+			leaves[i] = hashResult(fmt.Sprintf("round %d", i))
+		}
+
+		commitment := instance.BuildMerkleCommitment(day, leaves)
+		for _, leaf := range leaves {
+			proof, err := instance.GenerateMerkleProof(commitment, leaf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := instance.VerifyMerkleProof(commitment.Root, proof); err != nil {
+				t.Fatalf("%d leaves, leaf %d: %v", count, proof.Index, err)
+			}
+
+			proof.Leaf = hashResult("other round")
+			if err := instance.VerifyMerkleProof(commitment.Root, proof); err == nil {
+				t.Fatalf("expected error but got nil")
+			}
+		}
+	}
+
+	if _, err := instance.GenerateMerkleProof(instance.BuildMerkleCommitment(day, []string{"a"}), "b"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_BuildMerkleCommitment(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	day := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+
+	commitment := instance.BuildMerkleCommitment(day, []string{"a", "b", "c"})
+	ab := merkleNode(merkleLeaf("a"), merkleLeaf("b"))
+	root := hex.EncodeToString(merkleNode(ab, merkleLeaf("c")))
+	if commitment.Root != root || commitment.Day != "2026-10-19" {
+		t.Fatalf("expected %s %s, but got %s %s", "2026-10-19", root, commitment.Day, commitment.Root)
+	}
+
+	empty := sha512.Sum512(nil)
+	if root := instance.BuildMerkleCommitment(day, nil).Root; root != hex.EncodeToString(empty[:]) {
+		t.Fatalf("expected %x, but got %s", empty, root)
+	}
+}
+
+func TestLogic_CommitRoundsDay(t *testing.T) {
+	store, err := NewFileRoundStore(filepath.Join(t.TempDir(), "rounds.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	instance := New(os.Getenv("API_KEY"), WithRoundStore(store))
+	if _, err := New(os.Getenv("API_KEY")).CommitRoundsDay(context.Background(), time.Now()); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	yesterday := time.Now().UTC().Truncate(24 * time.Hour).Add(-time.Hour)
+	var hashes []string
+	for i := 0; i < 3; i++ {
+		hash := hashResult(fmt.Sprintf("round %d", i))
+		round := &Round{ID: hash, Game: "dice", Result: []byte(`{}`), CreatedAt: yesterday.Add(time.Duration(i) * time.Minute)}
+		if err := store.SaveCommitment(context.Background(), round); err != nil {
+			t.Fatal(err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if _, err := instance.GenerateDiceNumber(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.CommitRoundsDay(context.Background(), time.Now()); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	commitment, err := instance.CommitRoundsDay(context.Background(), yesterday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commitment.Leaves) != 3 {
+		t.Fatalf("expected 3 leaves, but got %d", len(commitment.Leaves))
+	}
+
+	proof, err := instance.GenerateMerkleProof(commitment, hashes[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := instance.VerifyMerkleProof(commitment.Root, proof); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLogic_RunDailyCommitments(t *testing.T) {
+	store, err := NewFileRoundStore(filepath.Join(t.TempDir(), "rounds.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	instance := New(os.Getenv("API_KEY"), WithRoundStore(store))
+	since := time.Now().UTC().Add(-48 * time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var days []string
+	err = instance.RunDailyCommitments(ctx, since, func(ctx context.Context, commitment *MerkleCommitment) error {
+		days = append(days, commitment.Day)
+		if len(days) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("expected %v, but got %v", context.Canceled, err)
+	}
+
+	expected := []string{since.Format("2006-01-02"), since.Add(24 * time.Hour).Format("2006-01-02")}
+	if len(days) != 2 || days[0] != expected[0] || days[1] != expected[1] {
+		t.Fatalf("expected %v, but got %v", expected, days)
+	}
+
+	failed := errors.New("failed")
+	err = instance.RunDailyCommitments(context.Background(), since, func(ctx context.Context, commitment *MerkleCommitment) error {
+		return failed
+	})
+	if err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
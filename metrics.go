@@ -0,0 +1,320 @@
+package logic
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics receives the measurements of Api and Logic. Outcome is "ok",
+// "error", or "api_error" when random.org answered with an error.
+type Metrics interface {
+	ObserveApiRequest(method string, outcome string, duration time.Duration)
+	IncApiRetry(method string)
+	SetApiUsage(bitsLeft int, requestsLeft int)
+	ObserveGeneration(game string, outcome string, duration time.Duration)
+	SetPoolDepth(kind string, depth int)
+}
+
+func WithMetrics(metrics Metrics) Option {
+	return func(l *Logic) {
+		l.metrics = metrics
+		l.api.metrics = metrics
+	}
+}
+
+func (l *Logic) observeGeneration(game string, result interface{}, err error, startedAt time.Time) {
+	duration := time.Since(startedAt)
+
+	if l.logger != nil {
+		if err != nil {
+			l.logger.Error("result generation failed", "game", game, "duration", duration, "error", err)
+		} else {
+			key, id := generationID(result)
+			l.logger.Info("result generated", "game", game, "duration", duration, key, id)
+		}
+	}
+
+	if l.metrics == nil {
+		return
+	}
+
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	l.metrics.ObserveGeneration(game, outcome, duration)
+}
+
+// MetricsBuckets are the upper bounds in seconds of the latency histograms.
+var MetricsBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func (h *histogram) observe(seconds float64) {
+	for i, bound := range MetricsBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// MetricsCollector is a Metrics kept in memory, exported in the Prometheus
+// text format by ServeHTTP and through expvar by Publish.
+type MetricsCollector struct {
+	mu           sync.Mutex
+	requests     map[[2]string]uint64
+	latencies    map[string]*histogram
+	retries      map[string]uint64
+	bitsLeft     int
+	requestsLeft int
+	generations  map[[2]string]*histogram
+	pool         map[string]int
+}
+
+func NewMetricsCollector() *MetricsCollector {
+	return &MetricsCollector{
+		requests:    make(map[[2]string]uint64),
+		latencies:   make(map[string]*histogram),
+		retries:     make(map[string]uint64),
+		generations: make(map[[2]string]*histogram),
+		pool:        make(map[string]int),
+	}
+}
+
+func newHistogram() *histogram {
+	return &histogram{counts: make([]uint64, len(MetricsBuckets))}
+}
+
+func (c *MetricsCollector) ObserveApiRequest(method string, outcome string, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests[[2]string{method, outcome}]++
+
+	latency, ok := c.latencies[method]
+	if !ok {
+		latency = newHistogram()
+		c.latencies[method] = latency
+	}
+	latency.observe(duration.Seconds())
+}
+
+func (c *MetricsCollector) IncApiRetry(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.retries[method]++
+}
+
+func (c *MetricsCollector) SetApiUsage(bitsLeft int, requestsLeft int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.bitsLeft = bitsLeft
+	c.requestsLeft = requestsLeft
+}
+
+func (c *MetricsCollector) ObserveGeneration(game string, outcome string, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := [2]string{game, outcome}
+	generation, ok := c.generations[key]
+	if !ok {
+		generation = newHistogram()
+		c.generations[key] = generation
+	}
+	generation.observe(duration.Seconds())
+}
+
+func (c *MetricsCollector) SetPoolDepth(kind string, depth int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pool[kind] = depth
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func writeHistogram(builder *strings.Builder, name string, labels string, h *histogram) {
+	for i, bound := range MetricsBuckets {
+		fmt.Fprintf(builder, "%s_bucket{%sle=\"%s\"} %d\n", name, labels, formatFloat(bound), h.counts[i])
+	}
+	fmt.Fprintf(builder, "%s_bucket{%sle=\"+Inf\"} %d\n", name, labels, h.count)
+	fmt.Fprintf(builder, "%s_sum{%s} %s\n", name, strings.TrimSuffix(labels, ","), formatFloat(h.sum))
+	fmt.Fprintf(builder, "%s_count{%s} %d\n", name, strings.TrimSuffix(labels, ","), h.count)
+}
+
+func sortedPairs(keys [][2]string) [][2]string {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
+
+func sortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+// Prometheus returns the metrics in the Prometheus text format.
+func (c *MetricsCollector) Prometheus() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var builder strings.Builder
+
+	builder.WriteString("# HELP coincup_api_requests_total Requests to random.org by method and outcome.\n")
+	builder.WriteString("# TYPE coincup_api_requests_total counter\n")
+	requests := make([][2]string, 0, len(c.requests))
+	for key := range c.requests {
+		requests = append(requests, key)
+	}
+	for _, key := range sortedPairs(requests) {
+		fmt.Fprintf(&builder, "coincup_api_requests_total{method=%q,outcome=%q} %d\n", key[0], key[1], c.requests[key])
+	}
+
+	builder.WriteString("# HELP coincup_api_request_duration_seconds Latency of requests to random.org including retries.\n")
+	builder.WriteString("# TYPE coincup_api_request_duration_seconds histogram\n")
+	methods := make([]string, 0, len(c.latencies))
+	for method := range c.latencies {
+		methods = append(methods, method)
+	}
+	for _, method := range sortedKeys(methods) {
+		writeHistogram(&builder, "coincup_api_request_duration_seconds", fmt.Sprintf("method=%q,", method), c.latencies[method])
+	}
+
+	builder.WriteString("# HELP coincup_api_retries_total Retried requests to random.org by method.\n")
+	builder.WriteString("# TYPE coincup_api_retries_total counter\n")
+	methods = methods[:0]
+	for method := range c.retries {
+		methods = append(methods, method)
+	}
+	for _, method := range sortedKeys(methods) {
+		fmt.Fprintf(&builder, "coincup_api_retries_total{method=%q} %d\n", method, c.retries[method])
+	}
+
+	builder.WriteString("# HELP coincup_api_bits_left Bits left in the random.org quota.\n")
+	builder.WriteString("# TYPE coincup_api_bits_left gauge\n")
+	fmt.Fprintf(&builder, "coincup_api_bits_left %d\n", c.bitsLeft)
+	builder.WriteString("# HELP coincup_api_requests_left Requests left in the random.org quota.\n")
+	builder.WriteString("# TYPE coincup_api_requests_left gauge\n")
+	fmt.Fprintf(&builder, "coincup_api_requests_left %d\n", c.requestsLeft)
+
+	builder.WriteString("# HELP coincup_generation_duration_seconds Latency of result generation by game and outcome.\n")
+	builder.WriteString("# TYPE coincup_generation_duration_seconds histogram\n")
+	generations := make([][2]string, 0, len(c.generations))
+	for key := range c.generations {
+		generations = append(generations, key)
+	}
+	for _, key := range sortedPairs(generations) {
+		writeHistogram(&builder, "coincup_generation_duration_seconds", fmt.Sprintf("game=%q,outcome=%q,", key[0], key[1]), c.generations[key])
+	}
+
+	builder.WriteString("# HELP coincup_pool_depth Ready commitments in the pool by kind.\n")
+	builder.WriteString("# TYPE coincup_pool_depth gauge\n")
+	kinds := make([]string, 0, len(c.pool))
+	for kind := range c.pool {
+		kinds = append(kinds, kind)
+	}
+	for _, kind := range sortedKeys(kinds) {
+		fmt.Fprintf(&builder, "coincup_pool_depth{kind=%q} %d\n", kind, c.pool[kind])
+	}
+
+	return builder.String()
+}
+
+func (c *MetricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(c.Prometheus()))
+}
+
+type histogramSnapshot struct {
+	Buckets []uint64 `json:"buckets"`
+	Sum     float64  `json:"sum"`
+	Count   uint64   `json:"count"`
+}
+
+type metricsSnapshot struct {
+	Requests     map[string]uint64            `json:"requests"`
+	Latencies    map[string]histogramSnapshot `json:"latencies"`
+	Retries      map[string]uint64            `json:"retries"`
+	BitsLeft     int                          `json:"bitsLeft"`
+	RequestsLeft int                          `json:"requestsLeft"`
+	Generations  map[string]histogramSnapshot `json:"generations"`
+	Pool         map[string]int               `json:"pool"`
+}
+
+func snapshotHistogram(h *histogram) histogramSnapshot {
+	return histogramSnapshot{
+		Buckets: append([]uint64(nil), h.counts...),
+		Sum:     h.sum,
+		Count:   h.count,
+	}
+}
+
+func (c *MetricsCollector) snapshot() interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := &metricsSnapshot{
+		Requests:     make(map[string]uint64, len(c.requests)),
+		Latencies:    make(map[string]histogramSnapshot, len(c.latencies)),
+		Retries:      make(map[string]uint64, len(c.retries)),
+		BitsLeft:     c.bitsLeft,
+		RequestsLeft: c.requestsLeft,
+		Generations:  make(map[string]histogramSnapshot, len(c.generations)),
+		Pool:         make(map[string]int, len(c.pool)),
+	}
+	for key, count := range c.requests {
+		snapshot.Requests[key[0]+"/"+key[1]] = count
+	}
+	for method, latency := range c.latencies {
+		snapshot.Latencies[method] = snapshotHistogram(latency)
+	}
+	for method, count := range c.retries {
+		snapshot.Retries[method] = count
+	}
+	for key, generation := range c.generations {
+		snapshot.Generations[key[0]+"/"+key[1]] = snapshotHistogram(generation)
+	}
+	for kind, depth := range c.pool {
+		snapshot.Pool[kind] = depth
+	}
+	return snapshot
+}
+
+// publishMu makes the check and the publishing of Publish atomic.
+var publishMu sync.Mutex
+
+// Publish exports the metrics through expvar under name, unless the name is
+// already in use.
+func (c *MetricsCollector) Publish(name string) error {
+	publishMu.Lock()
+	defer publishMu.Unlock()
+
+	if expvar.Get(name) != nil {
+		return errors.New("metrics name already in use")
+	}
+
+	expvar.Publish(name, expvar.Func(c.snapshot))
+	return nil
+}
@@ -0,0 +1,158 @@
+package logic
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":{"random":{"method":"generateSignedDecimalFractions","n":1,"decimalPlaces":3,"data":[0.5],"serialNumber":42},` +
+			`"signature":"signature","bitsUsed":10,"bitsLeft":249990,"requestsLeft":999},"id":1337}`))
+	}))
+	url := ApiUrl
+	ApiUrl = server.URL
+	defer func() {
+		ApiUrl = url
+		server.Close()
+	}()
+
+	collector := NewMetricsCollector()
+	instance := New(os.Getenv("API_KEY"), WithMetrics(collector))
+
+	coef, err := instance.GenerateCrashCoefficient(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coef.SerialNumber != 42 {
+		t.Fatalf("expected 42, but got %d", coef.SerialNumber)
+	}
+	if _, err := instance.GenerateDiceNumber(); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+
+	for _, line := range []string{
+		`coincup_api_requests_total{method="generateSignedDecimalFractions",outcome="ok"} 1`,
+		`coincup_api_request_duration_seconds_bucket{method="generateSignedDecimalFractions",le="+Inf"} 1`,
+		`coincup_api_request_duration_seconds_count{method="generateSignedDecimalFractions"} 1`,
+		`coincup_api_bits_left 249990`,
+		`coincup_api_requests_left 999`,
+		`coincup_generation_duration_seconds_count{game="crash",outcome="ok"} 1`,
+		`coincup_generation_duration_seconds_count{game="dice",outcome="ok"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Fatalf("expected \"%s\" in\n%s", line, body)
+		}
+	}
+}
+
+func TestMetricsCollector_Games(t *testing.T) {
+	collector := NewMetricsCollector()
+	instance := New(os.Getenv("API_KEY"), WithMetrics(collector))
+	ctx := context.Background()
+
+	for _, name := range []string{"limbo", "plinko", "keno", "coinflip", "cards-1", "tower-easy"} {
+		game, err := instance.Games().Game(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := game.Generate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := instance.GenerateSlotSpin(ctx, slotTestConfig()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.GenerateCaseOpening(ctx, caseTestCase()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.GenerateJackpotDraw(ctx, nil); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+
+	for _, game := range []string{"limbo", "plinko", "keno", "coinflip", "cards", "tower", "slots", "case"} {
+		line := fmt.Sprintf(`coincup_generation_duration_seconds_count{game="%s",outcome="ok"} 1`, game)
+		if !strings.Contains(body, line+"\n") {
+			t.Fatalf("expected \"%s\" in\n%s", line, body)
+		}
+	}
+	if line := `coincup_generation_duration_seconds_count{game="jackpot",outcome="error"} 1`; !strings.Contains(body, line+"\n") {
+		t.Fatalf("expected \"%s\" in\n%s", line, body)
+	}
+}
+
+func TestMetricsCollector_Retries(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	url := ApiUrl
+	ApiUrl = server.URL
+	defer func() { ApiUrl = url }()
+
+	collector := NewMetricsCollector()
+	instance := New(os.Getenv("API_KEY"), WithMetrics(collector), WithApiRetries(2))
+
+	if _, err := instance.GenerateDoubleNumber(context.Background()); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	body := collector.Prometheus()
+	for _, line := range []string{
+		`coincup_api_requests_total{method="generateSignedIntegers",outcome="error"} 1`,
+		`coincup_api_retries_total{method="generateSignedIntegers"} 2`,
+		`coincup_generation_duration_seconds_count{game="double",outcome="error"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Fatalf("expected \"%s\" in\n%s", line, body)
+		}
+	}
+}
+
+// publishCount keeps the expvar names of the test unique across runs of
+// go test -count.
+var publishCount int32
+
+func TestMetricsCollector_Publish(t *testing.T) {
+	collector := NewMetricsCollector()
+	collector.SetPoolDepth("dice", 3)
+	name := fmt.Sprintf("coincup_test_%d", atomic.AddInt32(&publishCount, 1))
+	if err := collector.Publish(name); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewMetricsCollector().Publish(name); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	value := expvar.Get(name).String()
+	if !strings.Contains(value, `"pool":{"dice":3}`) {
+		t.Fatalf("expected pool depth in %s", value)
+	}
+}
+
+func TestCommitmentPool_Metrics(t *testing.T) {
+	collector := NewMetricsCollector()
+	pool := New(os.Getenv("API_KEY"), WithMetrics(collector)).NewCommitmentPool(2, 1)
+	defer pool.Close()
+
+	pool.Warm("alice")
+	waitReady(t, pool, "alice", 2)
+
+	if body := collector.Prometheus(); !strings.Contains(body, `coincup_pool_depth{kind="mines"} 2`+"\n") {
+		t.Fatalf("expected pool depth in\n%s", body)
+	}
+}
@@ -0,0 +1,232 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PlinkoRisk uint8
+
+const (
+	PlinkoLow PlinkoRisk = iota
+	PlinkoMedium
+	PlinkoHigh
+)
+
+const (
+	PlinkoMinRows uint8 = 8
+	PlinkoMaxRows uint8 = 16
+)
+
+type PlinkoPath struct {
+	Directions []uint8
+	LeftSeed   string
+	RightSeed  string
+	Result     string
+	ResultHash string
+}
+
+// plinkoWeights are the shapes of the payout tables by risk and rows starting
+// from PlinkoMinRows. GeneratePlinkoCoefficients scales them to the house edge.
+var plinkoWeights = map[PlinkoRisk][][]float64{
+	PlinkoLow: {
+		{5.6, 2.1, 1.1, 1, 0.5, 1, 1.1, 2.1, 5.6},
+		{5.6, 2, 1.6, 1, 0.7, 0.7, 1, 1.6, 2, 5.6},
+		{8.9, 3, 1.4, 1.1, 1, 0.5, 1, 1.1, 1.4, 3, 8.9},
+		{8.4, 3, 1.9, 1.3, 1, 0.7, 0.7, 1, 1.3, 1.9, 3, 8.4},
+		{10, 3, 1.6, 1.4, 1.1, 1, 0.5, 1, 1.1, 1.4, 1.6, 3, 10},
+		{8.1, 4, 3, 1.9, 1.2, 0.9, 0.7, 0.7, 0.9, 1.2, 1.9, 3, 4, 8.1},
+		{7.1, 4, 1.9, 1.4, 1.3, 1.1, 1, 0.5, 1, 1.1, 1.3, 1.4, 1.9, 4, 7.1},
+		{15, 8, 3, 2, 1.5, 1.1, 1, 0.7, 0.7, 1, 1.1, 1.5, 2, 3, 8, 15},
+		{16, 9, 2, 1.4, 1.4, 1.2, 1.1, 1, 0.5, 1, 1.1, 1.2, 1.4, 1.4, 2, 9, 16},
+	},
+	PlinkoMedium: {
+		{13, 3, 1.3, 0.7, 0.4, 0.7, 1.3, 3, 13},
+		{18, 4, 1.7, 0.9, 0.5, 0.5, 0.9, 1.7, 4, 18},
+		{22, 5, 2, 1.4, 0.6, 0.4, 0.6, 1.4, 2, 5, 22},
+		{24, 6, 3, 1.8, 0.7, 0.5, 0.5, 0.7, 1.8, 3, 6, 24},
+		{33, 11, 4, 2, 1.1, 0.6, 0.3, 0.6, 1.1, 2, 4, 11, 33},
+		{43, 13, 6, 3, 1.3, 0.7, 0.4, 0.4, 0.7, 1.3, 3, 6, 13, 43},
+		{58, 15, 7, 4, 1.9, 1, 0.5, 0.2, 0.5, 1, 1.9, 4, 7, 15, 58},
+		{88, 18, 11, 5, 3, 1.3, 0.5, 0.3, 0.3, 0.5, 1.3, 3, 5, 11, 18, 88},
+		{110, 41, 10, 5, 3, 1.5, 1, 0.5, 0.3, 0.5, 1, 1.5, 3, 5, 10, 41, 110},
+	},
+	PlinkoHigh: {
+		{29, 4, 1.5, 0.3, 0.2, 0.3, 1.5, 4, 29},
+		{43, 7, 2, 0.6, 0.2, 0.2, 0.6, 2, 7, 43},
+		{76, 10, 3, 0.9, 0.3, 0.2, 0.3, 0.9, 3, 10, 76},
+		{120, 14, 5.2, 1.4, 0.4, 0.2, 0.2, 0.4, 1.4, 5.2, 14, 120},
+		{170, 24, 8.1, 2, 0.7, 0.2, 0.2, 0.2, 0.7, 2, 8.1, 24, 170},
+		{260, 37, 11, 4, 1, 0.2, 0.2, 0.2, 0.2, 1, 4, 11, 37, 260},
+		{420, 56, 18, 5, 1.9, 0.3, 0.2, 0.2, 0.2, 0.3, 1.9, 5, 18, 56, 420},
+		{620, 83, 27, 8, 3, 0.5, 0.2, 0.2, 0.2, 0.2, 0.5, 3, 8, 27, 83, 620},
+		{1000, 130, 26, 9, 4, 2, 0.2, 0.2, 0.2, 0.2, 0.2, 2, 4, 9, 26, 130, 1000},
+	},
+}
+
+func (l *Logic) plinkoWeights(rows uint8, risk PlinkoRisk) ([]float64, error) {
+	if rows < PlinkoMinRows || rows > PlinkoMaxRows {
+		return nil, errors.New("wrong rows count")
+	}
+
+	tables, ok := plinkoWeights[risk]
+	if !ok {
+		return nil, errors.New("wrong risk")
+	}
+
+	return tables[rows-PlinkoMinRows], nil
+}
+
+// plinkoChances returns how many of the 2^rows paths end in each bucket.
+func plinkoChances(rows uint8) []uint64 {
+	chances := make([]uint64, rows+1)
+	chances[0] = 1
+	for k := uint8(1); k <= rows; k++ {
+		chances[k] = chances[k-1] * uint64(rows-k+1) / uint64(k)
+	}
+	return chances
+}
+
+func (l *Logic) GeneratePlinkoCoefficients(rows uint8, risk PlinkoRisk) ([]float64, error) {
+	weights, err := l.plinkoWeights(rows, risk)
+	if err != nil {
+		return nil, err
+	}
+
+	chances := plinkoChances(rows)
+	var weightsReturn float64
+	for k, weight := range weights {
+		weightsReturn += weight * float64(chances[k])
+	}
+	weightsReturn /= float64(uint64(1) << rows)
+
+	result := make([]float64, len(weights))
+	for k, weight := range weights {
+		coefficient := weight * (1 - l.edge) / weightsReturn
+		result[k] = math.Floor(coefficient*100+1e-9) / 100
+	}
+	return result, nil
+}
+
+// PlinkoRTP returns the exact return to player of the payout table, computed
+// in hundredths over all 2^rows paths.
+func (l *Logic) PlinkoRTP(rows uint8, risk PlinkoRisk) (float64, error) {
+	coefficients, err := l.GeneratePlinkoCoefficients(rows, risk)
+	if err != nil {
+		return 0, err
+	}
+
+	chances := plinkoChances(rows)
+	var total uint64
+	for k, coefficient := range coefficients {
+		total += uint64(math.Round(coefficient*100)) * chances[k]
+	}
+
+	return float64(total) / float64(100*(uint64(1)<<rows)), nil
+}
+
+func (l *Logic) GeneratePlinkoPath(ctx context.Context) (*PlinkoPath, error) {
+	startedAt := time.Now()
+	path, err := l.generatePlinkoPath()
+	l.observeGeneration("plinko", path, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSeeded(ctx, path.ResultHash, path); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+func (l *Logic) generatePlinkoPath() (*PlinkoPath, error) {
+	directions := make([]uint8, PlinkoMaxRows)
+	for i := range directions {
+		direction, err := UniformInt(l.entropy, 2)
+		if err != nil {
+			return nil, err
+		}
+		directions[i] = uint8(direction)
+	}
+
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	join := joinUint8(directions, "|")
+
+	result := fmt.Sprintf("%s|%s|%s", leftSeed, join, rightSeed)
+
+	path := &PlinkoPath{
+		Directions: directions,
+		LeftSeed:   leftSeed,
+		RightSeed:  rightSeed,
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return path, nil
+}
+
+func (l *Logic) PlinkoPathFromString(result string) (*PlinkoPath, error) {
+	elems := strings.Split(result, "|")
+	if len(elems) != int(PlinkoMaxRows)+2 {
+		return nil, errors.New("wrong result string")
+	}
+
+	directions := make([]uint8, PlinkoMaxRows)
+	for i := range directions {
+		direction, err := strconv.ParseUint(elems[i+1], 10, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		if direction > 1 {
+			return nil, errors.New("wrong result string")
+		}
+
+		directions[i] = uint8(direction)
+	}
+
+	path := &PlinkoPath{
+		Directions: directions,
+		LeftSeed:   elems[0],
+		RightSeed:  elems[PlinkoMaxRows+1],
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return path, nil
+}
+
+// PlinkoBucket returns the bucket the ball lands in: the number of right
+// turns among the first rows directions.
+func (l *Logic) PlinkoBucket(path *PlinkoPath, rows uint8) (uint8, error) {
+	if rows < PlinkoMinRows || rows > PlinkoMaxRows || len(path.Directions) < int(rows) {
+		return 0, errors.New("wrong rows count")
+	}
+
+	var bucket uint8
+	for _, direction := range path.Directions[:rows] {
+		bucket += direction
+	}
+	return bucket, nil
+}
+
+func (l *Logic) SettlePlinko(path *PlinkoPath, rows uint8, risk PlinkoRisk) (float64, error) {
+	coefficients, err := l.GeneratePlinkoCoefficients(rows, risk)
+	if err != nil {
+		return 0, err
+	}
+
+	bucket, err := l.PlinkoBucket(path, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	return coefficients[bucket], nil
+}
@@ -0,0 +1,93 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestLogic_GeneratePlinkoCoefficients(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	coefficients, err := instance.GeneratePlinkoCoefficients(8, PlinkoLow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{5.37, 2.01, 1.05, 0.95, 0.47, 0.95, 1.05, 2.01, 5.37}
+	if !float64Compare(coefficients, expected) {
+		t.Fatalf("expected %v, but got %v", expected, coefficients)
+	}
+}
+
+func TestLogic_PlinkoRTP(t *testing.T) {
+	for _, edge := range []float64{0.01, DefaultHouseEdge} {
+		instance := New(os.Getenv("API_KEY"), WithHouseEdge(edge))
+		for _, risk := range []PlinkoRisk{PlinkoLow, PlinkoMedium, PlinkoHigh} {
+			for rows := PlinkoMinRows; rows <= PlinkoMaxRows; rows++ {
+				rtp, err := instance.PlinkoRTP(rows, risk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rtp > 1-edge+1e-9 || rtp < 1-edge-0.01 {
+					t.Fatalf("unexpected rtp %v for %d rows, risk %d, edge %v", rtp, rows, risk, edge)
+				}
+			}
+		}
+	}
+}
+
+func TestLogic_GeneratePlinkoCoefficientsWrongInput(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	if _, err := instance.GeneratePlinkoCoefficients(7, PlinkoLow); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.GeneratePlinkoCoefficients(17, PlinkoLow); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.GeneratePlinkoCoefficients(8, 3); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_PlinkoPathFromString(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	path, err := instance.GeneratePlinkoPath(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := instance.PlinkoPathFromString(path.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !uint8Compare(path.Directions, restored.Directions) || path.ResultHash != restored.ResultHash {
+		t.Fatalf("expected \"%v\", but got \"%v\"", path.Directions, restored.Directions)
+	}
+
+	if _, err := instance.PlinkoPathFromString("a|0|1|2|0|0|0|0|0|0|0|0|0|0|0|0|0|b"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_SettlePlinko(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	path, err := instance.PlinkoPathFromString("a|1|1|1|1|1|1|1|1|0|0|0|0|0|0|0|0|b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, err := instance.PlinkoBucket(path, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bucket != 8 {
+		t.Fatalf("expected 8, but got %d", bucket)
+	}
+
+	coefficient, err := instance.SettlePlinko(path, 8, PlinkoLow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coefficient != 5.37 {
+		t.Fatalf("expected 5.37, but got %v", coefficient)
+	}
+}
@@ -0,0 +1,257 @@
+package logic
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+type poolQueue struct {
+	key       string
+	mines     []*MinesAllocation
+	dice      []*DiceNumber
+	refilling bool
+	element   *list.Element
+}
+
+// CommitmentPool keeps up to size ready Mines allocations and Dice numbers
+// per key, e.g. a user or a table, and refills them in the background. At
+// most keys keys are kept, the least recently used one is dropped first.
+//
+// Commitments live in memory only and each is handed out once. With a round
+// store a commitment is recorded when it is handed out, so the ones dropped
+// with their key or lost on a restart never reach the store or its daily
+// commitments. The entropy of the Logic has to be safe for concurrent use, as
+// crypto/rand is.
+type CommitmentPool struct {
+	logic  *Logic
+	size   int
+	keys   int
+	mu     sync.Mutex
+	queues map[string]*poolQueue
+	recent *list.List
+	ready  [2]int
+	refill chan *poolQueue
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func (l *Logic) NewCommitmentPool(size int, keys int) *CommitmentPool {
+	if size < 1 {
+		size = 1
+	}
+	if keys < 1 {
+		keys = 1
+	}
+
+	pool := &CommitmentPool{
+		logic:  l,
+		size:   size,
+		keys:   keys,
+		queues: make(map[string]*poolQueue),
+		recent: list.New(),
+		refill: make(chan *poolQueue, keys),
+		done:   make(chan struct{}),
+	}
+
+	pool.wg.Add(1)
+	go pool.run()
+	return pool
+}
+
+// queue returns the queue of the key and marks it as recently used. It has to
+// be called with the lock held.
+func (p *CommitmentPool) queue(key string) *poolQueue {
+	if q, ok := p.queues[key]; ok {
+		p.recent.MoveToFront(q.element)
+		return q
+	}
+
+	q := &poolQueue{key: key}
+	q.element = p.recent.PushFront(q)
+	p.queues[key] = q
+
+	for len(p.queues) > p.keys {
+		oldest := p.recent.Remove(p.recent.Back()).(*poolQueue)
+		delete(p.queues, oldest.key)
+		p.setReady(-len(oldest.mines), -len(oldest.dice))
+	}
+	return q
+}
+
+// setReady moves the count of ready allocations and numbers of all keys by
+// the deltas. It has to be called with the lock held.
+func (p *CommitmentPool) setReady(mines int, dice int) {
+	p.ready[0] += mines
+	p.ready[1] += dice
+
+	if metrics := p.logic.metrics; metrics != nil {
+		metrics.SetPoolDepth("mines", p.ready[0])
+		metrics.SetPoolDepth("dice", p.ready[1])
+	}
+}
+
+// startRefill has to be called with the lock held.
+func (p *CommitmentPool) startRefill(q *poolQueue) {
+	if q.refilling || (len(q.mines) >= p.size && len(q.dice) >= p.size) {
+		return
+	}
+
+	select {
+	case p.refill <- q:
+		q.refilling = true
+	default:
+	}
+}
+
+func (p *CommitmentPool) run() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case q := <-p.refill:
+			p.fill(q)
+		}
+	}
+}
+
+func (p *CommitmentPool) fill(q *poolQueue) {
+	defer func() {
+		p.mu.Lock()
+		q.refilling = false
+		p.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+
+		p.mu.Lock()
+		current := p.queues[q.key] == q
+		needMines := len(q.mines) < p.size
+		needDice := len(q.dice) < p.size
+		p.mu.Unlock()
+
+		if !current || (!needMines && !needDice) {
+			return
+		}
+
+		if needMines {
+			allocation, err := p.logic.unrecordedMinesAllocation()
+			if err != nil {
+				return
+			}
+
+			p.mu.Lock()
+			if p.queues[q.key] == q {
+				q.mines = append(q.mines, allocation)
+				p.setReady(1, 0)
+			}
+			p.mu.Unlock()
+		}
+
+		if needDice {
+			number, err := p.logic.unrecordedDiceNumber()
+			if err != nil {
+				return
+			}
+
+			p.mu.Lock()
+			if p.queues[q.key] == q {
+				q.dice = append(q.dice, number)
+				p.setReady(0, 1)
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// Warm starts filling the queue of the key ahead of the first take.
+func (p *CommitmentPool) Warm(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.startRefill(p.queue(key))
+}
+
+// TakeMinesAllocation hands out a ready allocation of the key and records it
+// in the round store, or generates one if there is none.
+func (p *CommitmentPool) TakeMinesAllocation(key string) (*MinesAllocation, error) {
+	p.mu.Lock()
+	q := p.queue(key)
+
+	var allocation *MinesAllocation
+	if len(q.mines) > 0 {
+		allocation = q.mines[0]
+		q.mines[0] = nil
+		q.mines = q.mines[1:]
+		p.setReady(-1, 0)
+	}
+	p.startRefill(q)
+	p.mu.Unlock()
+
+	if allocation == nil {
+		return p.logic.GenerateMinesAllocation()
+	}
+
+	if err := p.logic.recordSeeded(context.Background(), allocation.ResultHash, allocation); err != nil {
+		return nil, err
+	}
+	return allocation, nil
+}
+
+// TakeDiceNumber hands out a ready number of the key and records it in the
+// round store, or generates one if there is none.
+func (p *CommitmentPool) TakeDiceNumber(key string) (*DiceNumber, error) {
+	p.mu.Lock()
+	q := p.queue(key)
+
+	var number *DiceNumber
+	if len(q.dice) > 0 {
+		number = q.dice[0]
+		q.dice[0] = nil
+		q.dice = q.dice[1:]
+		p.setReady(0, -1)
+	}
+	p.startRefill(q)
+	p.mu.Unlock()
+
+	if number == nil {
+		return p.logic.GenerateDiceNumber()
+	}
+
+	if err := p.logic.recordSeeded(context.Background(), number.ResultHash, number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+// Ready returns the count of ready allocations and numbers of the key.
+func (p *CommitmentPool) Ready(key string) (int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	q, ok := p.queues[key]
+	if !ok {
+		return 0, 0
+	}
+	return len(q.mines), len(q.dice)
+}
+
+// Close stops the background refill. Takes after Close generate on demand.
+func (p *CommitmentPool) Close() {
+	p.mu.Lock()
+	select {
+	case <-p.done:
+	default:
+		close(p.done)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
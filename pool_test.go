@@ -0,0 +1,132 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waitReady(t *testing.T, pool *CommitmentPool, key string, size int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		mines, dice := pool.Ready(key)
+		if mines == size && dice == size {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d ready commitments of \"%s\"", size, key)
+}
+
+func TestCommitmentPool_Take(t *testing.T) {
+	pool := New(os.Getenv("API_KEY")).NewCommitmentPool(4, 2)
+	defer pool.Close()
+
+	pool.Warm("alice")
+	waitReady(t, pool, "alice", 4)
+
+	allocation, err := pool.TakeMinesAllocation("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	number, err := pool.TakeDiceNumber("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allocation.Places) != 25 || number.Value >= DiceLength {
+		t.Fatalf("expected valid commitments, but got %v %d", allocation.Places, number.Value)
+	}
+	waitReady(t, pool, "alice", 4)
+
+	pool.Warm("bob")
+	pool.Warm("carol")
+	if mines, dice := pool.Ready("alice"); mines != 0 || dice != 0 {
+		t.Fatalf("expected \"alice\" to be dropped, but got %d %d", mines, dice)
+	}
+}
+
+func TestCommitmentPool_RoundStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileRoundStore(filepath.Join(t.TempDir(), "rounds.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	pool := New(os.Getenv("API_KEY"), WithRoundStore(store)).NewCommitmentPool(4, 1)
+	defer pool.Close()
+
+	pool.Warm("alice")
+	waitReady(t, pool, "alice", 4)
+
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+	if rounds, err := store.List(ctx, from, to); err != nil || len(rounds) != 0 {
+		t.Fatalf("expected no rounds, but got %d %v", len(rounds), err)
+	}
+
+	number, err := pool.TakeDiceNumber("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rounds, err := store.List(ctx, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rounds) != 1 || rounds[0].ID != number.ResultHash {
+		t.Fatalf("expected only round \"%s\", but got %d rounds", number.ResultHash, len(rounds))
+	}
+}
+
+func TestCommitmentPool_TakeOnce(t *testing.T) {
+	pool := New(os.Getenv("API_KEY")).NewCommitmentPool(8, 4)
+	defer pool.Close()
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				allocation, err := pool.TakeMinesAllocation("table")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				number, err := pool.TakeDiceNumber("table")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				mu.Lock()
+				if seen[allocation.ResultHash] || seen[number.ResultHash] {
+					t.Errorf("commitment handed out twice")
+				}
+				seen[allocation.ResultHash] = true
+				seen[number.ResultHash] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != 800 {
+		t.Fatalf("expected 800, but got %d", len(seen))
+	}
+}
+
+func TestCommitmentPool_Close(t *testing.T) {
+	pool := New(os.Getenv("API_KEY")).NewCommitmentPool(4, 1)
+	pool.Close()
+	pool.Close()
+
+	if _, err := pool.TakeDiceNumber("alice"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,271 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type RouletteNumber struct {
+	Value        int
+	Random       string
+	Signature    string
+	SerialNumber uint64
+}
+
+type RouletteBetType uint8
+
+const (
+	RouletteStraight RouletteBetType = iota
+	RouletteSplit
+	RouletteStreet
+	RouletteCorner
+	RouletteSixLine
+	RouletteDozen
+	RouletteColumn
+	RouletteRed
+	RouletteBlack
+	RouletteOdd
+	RouletteEven
+	RouletteLow
+	RouletteHigh
+)
+
+// RouletteBet lists the covered numbers for inside bets, the dozen or column
+// (1 to 3) for dozen and column bets, and nothing for even money bets.
+type RouletteBet struct {
+	Type    RouletteBetType
+	Numbers []uint8
+}
+
+const RouletteMaxNumber uint8 = 36
+
+var rouletteRed = map[uint8]bool{
+	1: true, 3: true, 5: true, 7: true, 9: true, 12: true, 14: true, 16: true, 18: true,
+	19: true, 21: true, 23: true, 25: true, 27: true, 30: true, 32: true, 34: true, 36: true,
+}
+
+func rouletteRange(from uint8, to uint8, step uint8) []uint8 {
+	result := make([]uint8, 0, (to-from)/step+1)
+	for n := from; n <= to; n += step {
+		result = append(result, n)
+	}
+	return result
+}
+
+func rouletteFilter(match func(n uint8) bool) []uint8 {
+	result := make([]uint8, 0, 18)
+	for n := uint8(1); n <= RouletteMaxNumber; n++ {
+		if match(n) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func rouletteInside(numbers []uint8, count int) ([]uint8, error) {
+	if len(numbers) != count {
+		return nil, errors.New("wrong bet numbers")
+	}
+
+	sorted := append([]uint8(nil), numbers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i, n := range sorted {
+		if n > RouletteMaxNumber || (i > 0 && sorted[i-1] == n) {
+			return nil, errors.New("wrong bet numbers")
+		}
+	}
+	return sorted, nil
+}
+
+func rouletteOutside(numbers []uint8) (uint8, error) {
+	if len(numbers) != 1 || numbers[0] < 1 || numbers[0] > 3 {
+		return 0, errors.New("wrong bet numbers")
+	}
+	return numbers[0], nil
+}
+
+// rouletteCovered validates the bet against the table layout, where row r
+// holds 3r-2, 3r-1 and 3r, and returns the numbers it covers.
+func rouletteCovered(bet *RouletteBet) ([]uint8, error) {
+	switch bet.Type {
+	case RouletteStraight:
+		return rouletteInside(bet.Numbers, 1)
+	case RouletteSplit:
+		n, err := rouletteInside(bet.Numbers, 2)
+		if err != nil {
+			return nil, err
+		}
+		a, b := n[0], n[1]
+		if (a == 0 && b <= 3) || b-a == 3 || (b-a == 1 && a%3 != 0) {
+			return n, nil
+		}
+	case RouletteStreet:
+		n, err := rouletteInside(bet.Numbers, 3)
+		if err != nil {
+			return nil, err
+		}
+		if n[0] == 0 && n[1] == n[2]-1 && n[2] <= 3 {
+			return n, nil
+		}
+		if n[0]%3 == 1 && n[1] == n[0]+1 && n[2] == n[0]+2 {
+			return n, nil
+		}
+	case RouletteCorner:
+		n, err := rouletteInside(bet.Numbers, 4)
+		if err != nil {
+			return nil, err
+		}
+		if n[0] == 0 && n[1] == 1 && n[2] == 2 && n[3] == 3 {
+			return n, nil
+		}
+		if n[0] != 0 && n[0]%3 != 0 && n[1] == n[0]+1 && n[2] == n[0]+3 && n[3] == n[0]+4 {
+			return n, nil
+		}
+	case RouletteSixLine:
+		n, err := rouletteInside(bet.Numbers, 6)
+		if err != nil {
+			return nil, err
+		}
+		if n[0]%3 == 1 && n[5] == n[0]+5 {
+			return n, nil
+		}
+	case RouletteDozen:
+		dozen, err := rouletteOutside(bet.Numbers)
+		if err != nil {
+			return nil, err
+		}
+		return rouletteRange(12*dozen-11, 12*dozen, 1), nil
+	case RouletteColumn:
+		column, err := rouletteOutside(bet.Numbers)
+		if err != nil {
+			return nil, err
+		}
+		return rouletteRange(column, RouletteMaxNumber, 3), nil
+	case RouletteRed, RouletteBlack, RouletteOdd, RouletteEven, RouletteLow, RouletteHigh:
+		if len(bet.Numbers) != 0 {
+			return nil, errors.New("wrong bet numbers")
+		}
+		switch bet.Type {
+		case RouletteRed:
+			return rouletteFilter(func(n uint8) bool { return rouletteRed[n] }), nil
+		case RouletteBlack:
+			return rouletteFilter(func(n uint8) bool { return !rouletteRed[n] }), nil
+		case RouletteOdd:
+			return rouletteFilter(func(n uint8) bool { return n%2 == 1 }), nil
+		case RouletteEven:
+			return rouletteFilter(func(n uint8) bool { return n%2 == 0 }), nil
+		case RouletteLow:
+			return rouletteRange(1, 18, 1), nil
+		default:
+			return rouletteRange(19, 36, 1), nil
+		}
+	default:
+		return nil, errors.New("wrong bet type")
+	}
+
+	return nil, errors.New("wrong bet numbers")
+}
+
+func (l *Logic) GenerateRouletteNumber(ctx context.Context) (*RouletteNumber, error) {
+	startedAt := time.Now()
+	number, err := l.generateRouletteNumber(ctx)
+	l.observeGeneration("roulette", number, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSigned(ctx, number.Random, number.SerialNumber, number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+func (l *Logic) generateRouletteNumber(ctx context.Context) (*RouletteNumber, error) {
+	integer, err := l.api.GenerateInteger(ctx, 0, int(RouletteMaxNumber))
+	if err != nil {
+		return nil, fmt.Errorf("random.org api error: %v", err)
+	}
+
+	number := &RouletteNumber{
+		Value:        integer.Value,
+		Random:       integer.Random,
+		Signature:    integer.Signature,
+		SerialNumber: integer.SerialNumber,
+	}
+	return number, nil
+}
+
+// VerifyRouletteNumber checks the random.org signature of the random object
+// and derives the number from it.
+func (l *Logic) VerifyRouletteNumber(ctx context.Context, random string, signature string) (*RouletteNumber, error) {
+	number, err := l.rouletteNumberFromRandom(random, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.verifySignature(ctx, random, signature); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+func (l *Logic) rouletteNumberFromRandom(random string, signature string) (*RouletteNumber, error) {
+	data := &integerResponseRandom{}
+	if err := unmarshal([]byte(random), data); err != nil {
+		return nil, err
+	}
+
+	max := int(RouletteMaxNumber)
+	if data.Method != "generateSignedIntegers" || data.Min != 0 || data.Max != max || len(data.Data) != 1 || data.Data[0] < 0 || data.Data[0] > max {
+		return nil, errors.New("wrong random")
+	}
+
+	number := &RouletteNumber{
+		Value:        data.Data[0],
+		Random:       random,
+		Signature:    signature,
+		SerialNumber: data.SerialNumber,
+	}
+	return number, nil
+}
+
+func (l *Logic) ValidateRouletteBet(bet *RouletteBet) error {
+	_, err := rouletteCovered(bet)
+	return err
+}
+
+// RouletteCoefficientByBet returns the payout coefficient including the stake,
+// 36 divided by the count of covered numbers.
+func (l *Logic) RouletteCoefficientByBet(bet *RouletteBet) (float64, error) {
+	covered, err := rouletteCovered(bet)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(RouletteMaxNumber) / float64(len(covered)), nil
+}
+
+func (l *Logic) SettleRouletteBets(number *RouletteNumber, bets []RouletteBet) ([]float64, error) {
+	if number.Value < 0 || number.Value > int(RouletteMaxNumber) {
+		return nil, errors.New("wrong roulette number")
+	}
+
+	result := make([]float64, len(bets))
+	for i := range bets {
+		covered, err := rouletteCovered(&bets[i])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range covered {
+			if int(n) == number.Value {
+				result[i] = float64(RouletteMaxNumber) / float64(len(covered))
+				break
+			}
+		}
+	}
+	return result, nil
+}
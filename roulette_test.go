@@ -0,0 +1,93 @@
+package logic
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLogic_GenerateRouletteNumberWrongKey(t *testing.T) {
+	instance := New("")
+	_, err := instance.GenerateRouletteNumber(context.Background())
+	if err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_ValidateRouletteBet(t *testing.T) {
+	instance := New("")
+	valid := []RouletteBet{
+		{Type: RouletteStraight, Numbers: []uint8{0}},
+		{Type: RouletteSplit, Numbers: []uint8{0, 3}},
+		{Type: RouletteSplit, Numbers: []uint8{17, 14}},
+		{Type: RouletteSplit, Numbers: []uint8{35, 36}},
+		{Type: RouletteStreet, Numbers: []uint8{0, 2, 3}},
+		{Type: RouletteStreet, Numbers: []uint8{34, 35, 36}},
+		{Type: RouletteCorner, Numbers: []uint8{0, 1, 2, 3}},
+		{Type: RouletteCorner, Numbers: []uint8{32, 33, 35, 36}},
+		{Type: RouletteSixLine, Numbers: []uint8{31, 32, 33, 34, 35, 36}},
+		{Type: RouletteDozen, Numbers: []uint8{3}},
+		{Type: RouletteColumn, Numbers: []uint8{1}},
+		{Type: RouletteRed},
+	}
+	for _, bet := range valid {
+		if err := instance.ValidateRouletteBet(&bet); err != nil {
+			t.Fatalf("unexpected error %v for %v", err, bet)
+		}
+	}
+
+	invalid := []RouletteBet{
+		{Type: RouletteStraight, Numbers: []uint8{37}},
+		{Type: RouletteSplit, Numbers: []uint8{3, 4}},
+		{Type: RouletteSplit, Numbers: []uint8{0, 4}},
+		{Type: RouletteStreet, Numbers: []uint8{2, 3, 4}},
+		{Type: RouletteCorner, Numbers: []uint8{3, 4, 6, 7}},
+		{Type: RouletteCorner, Numbers: []uint8{1, 1, 4, 5}},
+		{Type: RouletteSixLine, Numbers: []uint8{2, 3, 4, 5, 6, 7}},
+		{Type: RouletteDozen, Numbers: []uint8{4}},
+		{Type: RouletteColumn},
+		{Type: RouletteOdd, Numbers: []uint8{1}},
+		{Type: 100},
+	}
+	for _, bet := range invalid {
+		if err := instance.ValidateRouletteBet(&bet); err == nil {
+			t.Fatalf("expected error for %v but got nil", bet)
+		}
+	}
+}
+
+func TestLogic_SettleRouletteBets(t *testing.T) {
+	instance := New("")
+	bets := []RouletteBet{
+		{Type: RouletteStraight, Numbers: []uint8{17}},
+		{Type: RouletteSplit, Numbers: []uint8{17, 20}},
+		{Type: RouletteStreet, Numbers: []uint8{16, 17, 18}},
+		{Type: RouletteCorner, Numbers: []uint8{13, 14, 16, 17}},
+		{Type: RouletteSixLine, Numbers: []uint8{13, 14, 15, 16, 17, 18}},
+		{Type: RouletteDozen, Numbers: []uint8{2}},
+		{Type: RouletteColumn, Numbers: []uint8{2}},
+		{Type: RouletteRed},
+		{Type: RouletteBlack},
+		{Type: RouletteOdd},
+		{Type: RouletteEven},
+		{Type: RouletteLow},
+		{Type: RouletteHigh},
+	}
+	coefficients, err := instance.SettleRouletteBets(&RouletteNumber{Value: 17}, bets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{36, 18, 12, 9, 6, 3, 3, 0, 2, 2, 0, 2, 0}
+	if !float64Compare(coefficients, expected) {
+		t.Fatalf("expected %v, but got %v", expected, coefficients)
+	}
+
+	coefficients, err = instance.SettleRouletteBets(&RouletteNumber{Value: 0}, bets[5:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, coefficient := range coefficients {
+		if coefficient != 0 {
+			t.Fatalf("expected all outside bets to lose on zero, but got %v", coefficients)
+		}
+	}
+}
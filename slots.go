@@ -0,0 +1,377 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SlotSymbol pays Pays[n] times the line bet for n symbols in a row from the
+// leftmost reel, or for a scatter Pays[n] times the total bet for n symbols
+// anywhere in the window. A wild stands in for any symbol but a scatter.
+type SlotSymbol struct {
+	Name    string
+	Wild    bool
+	Scatter bool
+	Pays    []float64
+}
+
+// SlotConfig describes a machine: Reels are the strips of symbol indexes,
+// Rows is the height of the window and every payline holds a row per reel.
+// SlotConfig describes a slot machine. Name is the name its game is
+// registered under and the game of its encoded spins.
+type SlotConfig struct {
+	Name     string
+	Symbols  []SlotSymbol
+	Reels    [][]uint8
+	Rows     int
+	Paylines [][]uint8
+}
+
+type SlotSpin struct {
+	Game       string
+	Stops      []uint16
+	LeftSeed   string
+	RightSeed  string
+	Result     string
+	ResultHash string
+}
+
+type SlotLineWin struct {
+	Line        int
+	Symbol      uint8
+	Count       int
+	Coefficient float64
+}
+
+// SlotWin holds line wins as multiples of the line bet and Coefficient, the
+// total win as a multiple of the total bet.
+type SlotWin struct {
+	Window             [][]uint8
+	Lines              []SlotLineWin
+	Scatters           int
+	ScatterCoefficient float64
+	Coefficient        float64
+}
+
+func slotCents(pay float64) uint64 {
+	return uint64(math.Round(pay * 100))
+}
+
+func (l *Logic) ValidateSlotConfig(config *SlotConfig) error {
+	if config.Name == "" || len(config.Reels) == 0 || config.Rows < 1 || len(config.Paylines) == 0 {
+		return errors.New("wrong slot config")
+	}
+
+	for _, symbol := range config.Symbols {
+		if symbol.Wild && symbol.Scatter {
+			return errors.New("wrong slot symbol")
+		}
+		if len(symbol.Pays) > len(config.Reels)+1 {
+			return errors.New("wrong slot symbol")
+		}
+		for _, pay := range symbol.Pays {
+			if pay < 0 {
+				return errors.New("wrong slot symbol")
+			}
+		}
+	}
+
+	for _, strip := range config.Reels {
+		if len(strip) == 0 || len(strip) > math.MaxUint16+1 {
+			return errors.New("wrong reel strip")
+		}
+		for _, symbol := range strip {
+			if int(symbol) >= len(config.Symbols) {
+				return errors.New("wrong reel strip")
+			}
+		}
+	}
+
+	for _, line := range config.Paylines {
+		if len(line) != len(config.Reels) {
+			return errors.New("wrong payline")
+		}
+		for _, row := range line {
+			if int(row) >= config.Rows {
+				return errors.New("wrong payline")
+			}
+		}
+	}
+	return nil
+}
+
+func slotWindow(config *SlotConfig, stops []uint16, window [][]uint8) {
+	for reel, strip := range config.Reels {
+		for row := 0; row < config.Rows; row++ {
+			window[reel][row] = strip[(int(stops[reel])+row)%len(strip)]
+		}
+	}
+}
+
+func slotPay(symbol *SlotSymbol, count int) uint64 {
+	if count >= len(symbol.Pays) {
+		return 0
+	}
+	return slotCents(symbol.Pays[count])
+}
+
+// slotLine returns the best pay in hundredths of the line bet, the paying
+// symbol and the count of symbols in a row from the leftmost reel.
+func slotLine(config *SlotConfig, window [][]uint8, line []uint8) (uint64, uint8, int) {
+	first := window[0][line[0]]
+	if config.Symbols[first].Scatter {
+		return 0, 0, 0
+	}
+
+	wilds := 0
+	for reel := range line {
+		if !config.Symbols[window[reel][line[reel]]].Wild {
+			break
+		}
+		wilds++
+	}
+
+	var pay uint64
+	var symbol uint8
+	count := 0
+	if wilds > 0 {
+		pay = slotPay(&config.Symbols[first], wilds)
+		symbol = first
+		count = wilds
+	}
+
+	if wilds < len(line) {
+		target := window[wilds][line[wilds]]
+		if !config.Symbols[target].Scatter {
+			matched := wilds
+			for reel := wilds; reel < len(line); reel++ {
+				current := window[reel][line[reel]]
+				if current != target && !config.Symbols[current].Wild {
+					break
+				}
+				matched++
+			}
+
+			if targetPay := slotPay(&config.Symbols[target], matched); targetPay > pay {
+				pay, symbol, count = targetPay, target, matched
+			}
+		}
+	}
+
+	return pay, symbol, count
+}
+
+func slotScatters(config *SlotConfig, window [][]uint8, counts []int) []int {
+	for i := range counts {
+		counts[i] = 0
+	}
+	for _, column := range window {
+		for _, symbol := range column {
+			if config.Symbols[symbol].Scatter {
+				counts[symbol]++
+			}
+		}
+	}
+	return counts
+}
+
+func newSlotWindow(config *SlotConfig) [][]uint8 {
+	window := make([][]uint8, len(config.Reels))
+	for reel := range window {
+		window[reel] = make([]uint8, config.Rows)
+	}
+	return window
+}
+
+func joinUint16(elems []uint16, sep string) string {
+	var builder strings.Builder
+
+	for i, e := range elems {
+		builder.WriteString(strconv.FormatUint(uint64(e), 10))
+		if i+1 < len(elems) {
+			builder.WriteString(sep)
+		}
+	}
+
+	return builder.String()
+}
+
+func (l *Logic) GenerateSlotSpin(ctx context.Context, config *SlotConfig) (*SlotSpin, error) {
+	startedAt := time.Now()
+	spin, err := l.generateSlotSpin(config)
+	l.observeGeneration("slots", spin, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSeeded(ctx, spin.ResultHash, spin); err != nil {
+		return nil, err
+	}
+	return spin, nil
+}
+
+func (l *Logic) generateSlotSpin(config *SlotConfig) (*SlotSpin, error) {
+	if err := l.ValidateSlotConfig(config); err != nil {
+		return nil, err
+	}
+
+	stops := make([]uint16, len(config.Reels))
+	for reel, strip := range config.Reels {
+		stop, err := UniformInt(l.entropy, uint64(len(strip)))
+		if err != nil {
+			return nil, err
+		}
+		stops[reel] = uint16(stop)
+	}
+
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	join := joinUint16(stops, "|")
+
+	result := fmt.Sprintf("%s|%s|%s", leftSeed, join, rightSeed)
+
+	spin := &SlotSpin{
+		Game:       config.Name,
+		Stops:      stops,
+		LeftSeed:   leftSeed,
+		RightSeed:  rightSeed,
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return spin, nil
+}
+
+func (l *Logic) SlotSpinFromString(config *SlotConfig, result string) (*SlotSpin, error) {
+	elems := strings.Split(result, "|")
+	if len(elems) != len(config.Reels)+2 {
+		return nil, errors.New("wrong result string")
+	}
+
+	stops := make([]uint16, len(config.Reels))
+	for reel, strip := range config.Reels {
+		stop, err := strconv.ParseUint(elems[reel+1], 10, 16)
+		if err != nil {
+			return nil, err
+		}
+
+		if int(stop) >= len(strip) {
+			return nil, errors.New("wrong result string")
+		}
+
+		stops[reel] = uint16(stop)
+	}
+
+	spin := &SlotSpin{
+		Game:       config.Name,
+		Stops:      stops,
+		LeftSeed:   elems[0],
+		RightSeed:  elems[len(elems)-1],
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return spin, nil
+}
+
+func (l *Logic) EvaluateSlotSpin(config *SlotConfig, spin *SlotSpin) (*SlotWin, error) {
+	if err := l.ValidateSlotConfig(config); err != nil {
+		return nil, err
+	}
+
+	if len(spin.Stops) != len(config.Reels) {
+		return nil, errors.New("wrong slot spin")
+	}
+	for reel, stop := range spin.Stops {
+		if int(stop) >= len(config.Reels[reel]) {
+			return nil, errors.New("wrong slot spin")
+		}
+	}
+
+	window := newSlotWindow(config)
+	slotWindow(config, spin.Stops, window)
+
+	win := &SlotWin{
+		Window: window,
+	}
+
+	var total uint64
+	for i, line := range config.Paylines {
+		pay, symbol, count := slotLine(config, window, line)
+		if pay == 0 {
+			continue
+		}
+
+		win.Lines = append(win.Lines, SlotLineWin{
+			Line:        i,
+			Symbol:      symbol,
+			Count:       count,
+			Coefficient: float64(pay) / 100,
+		})
+		total += pay
+	}
+
+	var scatter uint64
+	for symbol, count := range slotScatters(config, window, make([]int, len(config.Symbols))) {
+		if count == 0 {
+			continue
+		}
+		win.Scatters += count
+		scatter += slotPay(&config.Symbols[symbol], count)
+	}
+
+	lines := uint64(len(config.Paylines))
+	win.ScatterCoefficient = float64(scatter) / 100
+	win.Coefficient = float64(total+scatter*lines) / float64(100*lines)
+	return win, nil
+}
+
+// SlotRTP returns the exact return to player of the machine by evaluating
+// every combination of reel stops.
+func (l *Logic) SlotRTP(config *SlotConfig) (float64, error) {
+	if err := l.ValidateSlotConfig(config); err != nil {
+		return 0, err
+	}
+
+	lines := uint64(len(config.Paylines))
+	window := newSlotWindow(config)
+	stops := make([]uint16, len(config.Reels))
+	counts := make([]int, len(config.Symbols))
+
+	var total uint64
+	var combinations uint64
+	for {
+		slotWindow(config, stops, window)
+
+		for _, line := range config.Paylines {
+			pay, _, _ := slotLine(config, window, line)
+			total += pay
+		}
+		for symbol, count := range slotScatters(config, window, counts) {
+			if count > 0 {
+				total += slotPay(&config.Symbols[symbol], count) * lines
+			}
+		}
+		combinations++
+
+		reel := len(stops) - 1
+		for ; reel >= 0; reel-- {
+			stops[reel]++
+			if int(stops[reel]) < len(config.Reels[reel]) {
+				break
+			}
+			stops[reel] = 0
+		}
+		if reel < 0 {
+			break
+		}
+	}
+
+	return float64(total) / float64(100*lines*combinations), nil
+}
@@ -0,0 +1,102 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func slotTestConfig() *SlotConfig {
+	return &SlotConfig{
+		Name: "slots",
+		Symbols: []SlotSymbol{
+			{Name: "A", Pays: []float64{0, 0, 0, 10}},
+			{Name: "B", Pays: []float64{0, 0, 2, 5}},
+			{Name: "W", Wild: true, Pays: []float64{0, 0, 0, 20}},
+			{Name: "S", Scatter: true, Pays: []float64{0, 0, 1, 50}},
+		},
+		Reels:    [][]uint8{{0, 1, 2, 3}, {0, 1, 2, 3}, {0, 1, 2, 3}},
+		Rows:     1,
+		Paylines: [][]uint8{{0, 0, 0}},
+	}
+}
+
+func TestLogic_SlotRTP(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	rtp, err := instance.SlotRTP(slotTestConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rtp != 3.0625 {
+		t.Fatalf("expected 3.0625, but got %v", rtp)
+	}
+}
+
+func TestLogic_EvaluateSlotSpin(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	config := slotTestConfig()
+
+	cases := map[string]float64{
+		"a|2|1|1|b": 5,
+		"a|2|2|0|b": 10,
+		"a|2|2|2|b": 20,
+		"a|1|1|0|b": 2,
+		"a|3|0|3|b": 1,
+		"a|3|3|3|b": 50,
+		"a|0|1|2|b": 0,
+	}
+	for result, expected := range cases {
+		spin, err := instance.SlotSpinFromString(config, result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		win, err := instance.EvaluateSlotSpin(config, spin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if win.Coefficient != expected {
+			t.Fatalf("expected %v for %s, but got %v", expected, result, win.Coefficient)
+		}
+	}
+}
+
+func TestLogic_GenerateSlotSpin(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	config := slotTestConfig()
+	spin, err := instance.GenerateSlotSpin(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := instance.SlotSpinFromString(config, spin.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.ResultHash != spin.ResultHash || len(restored.Stops) != len(spin.Stops) {
+		t.Fatalf("expected \"%v\", but got \"%v\"", spin.Stops, restored.Stops)
+	}
+}
+
+func TestLogic_ValidateSlotConfig(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	if err := instance.ValidateSlotConfig(slotTestConfig()); err != nil {
+		t.Fatal(err)
+	}
+
+	config := slotTestConfig()
+	config.Reels[1] = []uint8{4}
+	if err := instance.ValidateSlotConfig(config); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	config = slotTestConfig()
+	config.Paylines = [][]uint8{{0, 1, 0}}
+	if err := instance.ValidateSlotConfig(config); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	config = slotTestConfig()
+	if _, err := instance.SlotSpinFromString(config, "a|4|0|0|b"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
@@ -0,0 +1,255 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrRoundNotFound = errors.New("round not found")
+	ErrRoundExists   = errors.New("round already exists")
+	ErrRoundRevealed = errors.New("round already revealed")
+)
+
+// Round is a generated result as recorded before it is handed out. ID is the
+// result hash for seeded games and the SHA-512 of the random object for games
+// signed by random.org, which also set Serial. Result holds the encoded
+// result (see DecodeResult) and must not be shown before the round is
+// revealed. Signed rounds are public and recorded as revealed.
+type Round struct {
+	ID         string          `json:"id"`
+	Game       string          `json:"game"`
+	Serial     uint64          `json:"serial,omitempty"`
+	Result     json.RawMessage `json:"result"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	RevealedAt *time.Time      `json:"revealedAt,omitempty"`
+}
+
+type RoundStore interface {
+	SaveCommitment(ctx context.Context, round *Round) error
+	Reveal(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (*Round, error)
+	GetBySerial(ctx context.Context, serial uint64) (*Round, error)
+	// List returns the rounds created in [from, to) ordered by creation time.
+	List(ctx context.Context, from time.Time, to time.Time) ([]*Round, error)
+}
+
+func WithRoundStore(store RoundStore) Option {
+	return func(l *Logic) {
+		l.store = store
+	}
+}
+
+// recordRound saves the result into the round store, if there is one.
+func (l *Logic) recordRound(ctx context.Context, id string, serial uint64, revealed bool, result interface{}) error {
+	if l.store == nil {
+		return nil
+	}
+
+	data, err := marshal(result)
+	if err != nil {
+		return err
+	}
+
+	header := &resultHeader{}
+	if err := unmarshal(data, header); err != nil {
+		return err
+	}
+
+	round := &Round{
+		ID:        id,
+		Game:      header.Game,
+		Serial:    serial,
+		Result:    data,
+		CreatedAt: time.Now().UTC(),
+	}
+	if revealed {
+		round.RevealedAt = &round.CreatedAt
+	}
+
+	if err := l.store.SaveCommitment(ctx, round); err != nil {
+		return fmt.Errorf("round store error: %v", err)
+	}
+	return nil
+}
+
+func (l *Logic) recordSigned(ctx context.Context, random string, serial uint64, result interface{}) error {
+	return l.recordRound(ctx, hashResult(random), serial, true, result)
+}
+
+func (l *Logic) recordSeeded(ctx context.Context, resultHash string, result interface{}) error {
+	return l.recordRound(ctx, resultHash, 0, false, result)
+}
+
+type fileRecord struct {
+	Op    string     `json:"op"`
+	Round *Round     `json:"round,omitempty"`
+	ID    string     `json:"id,omitempty"`
+	At    *time.Time `json:"at,omitempty"`
+}
+
+// FileRoundStore is a RoundStore backed by an append-only file of JSON lines,
+// synced to disk on every write. There is no index file: the index by ID,
+// serial and creation time is only kept in memory and rebuilt by replaying
+// the whole file on open, so opening takes time and memory in proportion to
+// the number of rounds. A last line torn by a crash is cut off on open.
+type FileRoundStore struct {
+	mu      sync.RWMutex
+	log     *appendLog
+	rounds  map[string]*Round
+	serials map[uint64]string
+	order   []string
+}
+
+func NewFileRoundStore(path string) (*FileRoundStore, error) {
+	store := &FileRoundStore{
+		rounds:  make(map[string]*Round),
+		serials: make(map[uint64]string),
+	}
+
+	log, err := openAppendLog(path, func(offset int64, line []byte) error {
+		record := &fileRecord{}
+		if err := unmarshal(line, record); err != nil {
+			return fmt.Errorf("wrong round record at offset %d: %v", offset, err)
+		}
+		if err := store.apply(record); err != nil {
+			return fmt.Errorf("wrong round record at offset %d: %v", offset, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	store.log = log
+	return store, nil
+}
+
+func (s *FileRoundStore) apply(record *fileRecord) error {
+	switch record.Op {
+	case "save":
+		if record.Round == nil || record.Round.ID == "" {
+			return errors.New("wrong round")
+		}
+		if _, ok := s.rounds[record.Round.ID]; ok {
+			return ErrRoundExists
+		}
+		s.rounds[record.Round.ID] = record.Round
+		if record.Round.Serial != 0 {
+			s.serials[record.Round.Serial] = record.Round.ID
+		}
+		s.order = append(s.order, record.Round.ID)
+	case "reveal":
+		round, ok := s.rounds[record.ID]
+		if !ok {
+			return ErrRoundNotFound
+		}
+		if round.RevealedAt != nil {
+			return ErrRoundRevealed
+		}
+		round.RevealedAt = record.At
+	default:
+		return errors.New("wrong round record")
+	}
+	return nil
+}
+
+func (s *FileRoundStore) SaveCommitment(ctx context.Context, round *Round) error {
+	if round.ID == "" {
+		return errors.New("wrong round")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rounds[round.ID]; ok {
+		return ErrRoundExists
+	}
+
+	saved := *round
+	saved.Result = append(json.RawMessage(nil), round.Result...)
+	record := &fileRecord{Op: "save", Round: &saved}
+	if err := s.log.append(record); err != nil {
+		return err
+	}
+	return s.apply(record)
+}
+
+func (s *FileRoundStore) Reveal(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	round, ok := s.rounds[id]
+	if !ok {
+		return ErrRoundNotFound
+	}
+	if round.RevealedAt != nil {
+		return ErrRoundRevealed
+	}
+
+	at := time.Now().UTC()
+	record := &fileRecord{Op: "reveal", ID: id, At: &at}
+	if err := s.log.append(record); err != nil {
+		return err
+	}
+	return s.apply(record)
+}
+
+func copyRound(round *Round) *Round {
+	copied := *round
+	copied.Result = append(json.RawMessage(nil), round.Result...)
+	if round.RevealedAt != nil {
+		at := *round.RevealedAt
+		copied.RevealedAt = &at
+	}
+	return &copied
+}
+
+func (s *FileRoundStore) Get(ctx context.Context, id string) (*Round, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	round, ok := s.rounds[id]
+	if !ok {
+		return nil, ErrRoundNotFound
+	}
+	return copyRound(round), nil
+}
+
+func (s *FileRoundStore) GetBySerial(ctx context.Context, serial uint64) (*Round, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.serials[serial]
+	if !ok {
+		return nil, ErrRoundNotFound
+	}
+	return copyRound(s.rounds[id]), nil
+}
+
+func (s *FileRoundStore) List(ctx context.Context, from time.Time, to time.Time) ([]*Round, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rounds []*Round
+	for _, id := range s.order {
+		round := s.rounds[id]
+		if !round.CreatedAt.Before(from) && round.CreatedAt.Before(to) {
+			rounds = append(rounds, copyRound(round))
+		}
+	}
+
+	sort.SliceStable(rounds, func(i, j int) bool { return rounds[i].CreatedAt.Before(rounds[j].CreatedAt) })
+	return rounds, nil
+}
+
+func (s *FileRoundStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.log.Close()
+}
@@ -0,0 +1,233 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileRoundStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "rounds.jsonl")
+
+	store, err := NewFileRoundStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instance := New(os.Getenv("API_KEY"), WithEntropy(NewHashStream("store")), WithRoundStore(store))
+	from := time.Now().UTC().Add(-time.Second)
+
+	number, err := instance.GenerateDiceNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	allocation, err := instance.GenerateMinesAllocation()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := &Round{ID: "signed", Game: "crash", Serial: 42, Result: []byte(`{}`), CreatedAt: time.Now().UTC()}
+	signed.RevealedAt = &signed.CreatedAt
+	if err := store.SaveCommitment(ctx, signed); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveCommitment(ctx, signed); err != ErrRoundExists {
+		t.Fatalf("expected %v, but got %v", ErrRoundExists, err)
+	}
+
+	if err := store.Reveal(ctx, number.ResultHash); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Reveal(ctx, number.ResultHash); err != ErrRoundRevealed {
+		t.Fatalf("expected %v, but got %v", ErrRoundRevealed, err)
+	}
+	if err := store.Reveal(ctx, "unknown"); err != ErrRoundNotFound {
+		t.Fatalf("expected %v, but got %v", ErrRoundNotFound, err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.WriteString(`{"op":"save","round":{"id":"torn"`); err != nil {
+		t.Fatal(err)
+	}
+	_ = file.Close()
+
+	store, err = NewFileRoundStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	round, err := store.Get(ctx, number.ResultHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if round.Game != "dice" || round.RevealedAt == nil {
+		t.Fatalf("expected revealed dice round, but got %+v", round)
+	}
+
+	copied, err := store.Get(ctx, number.ResultHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copied.Result[0] = 'x'
+	if stored, err := store.Get(ctx, number.ResultHash); err != nil || stored.Result[0] != '{' {
+		t.Fatalf("expected the stored result unchanged, but got %s %v", stored.Result, err)
+	}
+
+	decoded, err := instance.DecodeResult(round.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.(*DiceNumber).Result != number.Result {
+		t.Fatalf("expected \"%s\", but got \"%s\"", number.Result, decoded.(*DiceNumber).Result)
+	}
+
+	round, err = store.Get(ctx, allocation.ResultHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if round.Game != "mines" || round.RevealedAt != nil {
+		t.Fatalf("expected unrevealed mines round, but got %+v", round)
+	}
+
+	round, err = store.GetBySerial(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if round.ID != "signed" {
+		t.Fatalf("expected \"signed\", but got \"%s\"", round.ID)
+	}
+
+	if _, err := store.Get(ctx, "torn"); err != ErrRoundNotFound {
+		t.Fatalf("expected %v, but got %v", ErrRoundNotFound, err)
+	}
+
+	rounds, err := store.List(ctx, from, time.Now().UTC().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rounds) != 3 || rounds[0].ID != number.ResultHash {
+		t.Fatalf("expected 3 rounds starting with dice, but got %d", len(rounds))
+	}
+
+	rounds, err = store.List(ctx, from.Add(-time.Hour), from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rounds) != 0 {
+		t.Fatalf("expected 0 rounds, but got %d", len(rounds))
+	}
+
+	instance = New(os.Getenv("API_KEY"), WithRoundStore(store))
+	next, err := instance.GenerateDiceNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, next.ResultHash); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "torn"); err != ErrRoundNotFound {
+		t.Fatalf("expected %v, but got %v", ErrRoundNotFound, err)
+	}
+}
+
+func TestFileRoundStoreReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "rounds.jsonl")
+
+	store, err := NewFileRoundStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance := New(os.Getenv("API_KEY"), WithRoundStore(store))
+	number, err := instance.GenerateDiceNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+
+	store, err = NewFileRoundStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	if _, err := store.Get(ctx, number.ResultHash); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type failingAuditSink struct{}
+
+func (s *failingAuditSink) WriteAuditEntry(entry *AuditEntry) error {
+	return errors.New("disk full")
+}
+
+func (s *failingAuditSink) LastAuditEntry() (*AuditEntry, error) {
+	return nil, nil
+}
+
+type contextKey struct{}
+
+type contextRoundStore struct {
+	*FileRoundStore
+	values []interface{}
+}
+
+func (s *contextRoundStore) SaveCommitment(ctx context.Context, round *Round) error {
+	s.values = append(s.values, ctx.Value(contextKey{}))
+	return s.FileRoundStore.SaveCommitment(ctx, round)
+}
+
+func TestLogic_RecordRoundAfterAudit(t *testing.T) {
+	store, err := NewFileRoundStore(filepath.Join(t.TempDir(), "rounds.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	instance := New(os.Getenv("API_KEY"), WithRoundStore(store), WithAuditSink(&failingAuditSink{}))
+	if _, err := instance.GenerateDiceNumber(); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+
+	rounds, err := store.List(context.Background(), time.Time{}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rounds) != 0 {
+		t.Fatalf("expected no rounds, but got %d", len(rounds))
+	}
+}
+
+func TestLogic_RecordRoundContext(t *testing.T) {
+	file, err := NewFileRoundStore(filepath.Join(t.TempDir(), "rounds.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	store := &contextRoundStore{FileRoundStore: file}
+	instance := New(os.Getenv("API_KEY"), WithRoundStore(store))
+	ctx := context.WithValue(context.Background(), contextKey{}, "caller")
+
+	if _, err := instance.GenerateDiceNumberContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.GenerateKenoDraw(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.values) != 2 || store.values[0] != "caller" || store.values[1] != "caller" {
+		t.Fatalf("expected the caller context, but got %v", store.values)
+	}
+}
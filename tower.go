@@ -0,0 +1,254 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TowerDifficulty uint8
+
+const (
+	TowerEasy TowerDifficulty = iota
+	TowerMedium
+	TowerHard
+	TowerExpert
+	TowerMaster
+)
+
+const TowerRows = 9
+
+// towerLayouts holds the number of safe tiles and the row width by difficulty.
+var towerLayouts = map[TowerDifficulty][2]uint8{
+	TowerEasy:   {3, 4},
+	TowerMedium: {2, 3},
+	TowerHard:   {1, 2},
+	TowerExpert: {1, 3},
+	TowerMaster: {1, 4},
+}
+
+// TowerAllocation holds a shuffled row of tiles from 1 to the row width for
+// every row, the first width-safe tiles of a row are traps.
+type TowerAllocation struct {
+	Difficulty TowerDifficulty
+	Rows       [][]uint8
+	LeftSeed   string
+	RightSeed  string
+	Result     string
+	ResultHash string
+}
+
+type TowerGame struct {
+	Allocation  *TowerAllocation
+	Picks       []uint8
+	Finished    bool
+	Won         bool
+	Coefficient float64
+}
+
+func towerLayout(difficulty TowerDifficulty) (uint8, uint8, error) {
+	layout, ok := towerLayouts[difficulty]
+	if !ok {
+		return 0, 0, errors.New("wrong difficulty")
+	}
+	return layout[0], layout[1], nil
+}
+
+func (l *Logic) GenerateTowerCoefficients(difficulty TowerDifficulty) ([]float64, error) {
+	safe, width, err := towerLayout(difficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	chances := make([]float64, TowerRows)
+	for i := range chances {
+		chances[i] = float64(safe) / float64(width)
+	}
+	return l.survivalCoefficients(chances), nil
+}
+
+func (l *Logic) GenerateTowerAllocation(ctx context.Context, difficulty TowerDifficulty) (*TowerAllocation, error) {
+	startedAt := time.Now()
+	allocation, err := l.generateTowerAllocation(difficulty)
+	l.observeGeneration("tower", allocation, err, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.recordSeeded(ctx, allocation.ResultHash, allocation); err != nil {
+		return nil, err
+	}
+	return allocation, nil
+}
+
+func (l *Logic) generateTowerAllocation(difficulty TowerDifficulty) (*TowerAllocation, error) {
+	_, width, err := towerLayout(difficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]uint8, TowerRows)
+	joins := make([]string, TowerRows)
+	for i := range rows {
+		base := make([]uint8, width)
+		for j := range base {
+			base[j] = uint8(j + 1)
+		}
+
+		rows[i], err = l.allocate(base, int(width))
+		if err != nil {
+			return nil, err
+		}
+		joins[i] = joinUint8(rows[i], "|")
+	}
+
+	leftSeed, rightSeed, err := l.generateSeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	result := fmt.Sprintf("%s|%s|%s", leftSeed, strings.Join(joins, "|"), rightSeed)
+
+	allocation := &TowerAllocation{
+		Difficulty: difficulty,
+		Rows:       rows,
+		LeftSeed:   leftSeed,
+		RightSeed:  rightSeed,
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return allocation, nil
+}
+
+func (l *Logic) TowerAllocationFromString(result string, difficulty TowerDifficulty) (*TowerAllocation, error) {
+	_, width, err := towerLayout(difficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := strings.Split(result, "|")
+	if len(elems) != TowerRows*int(width)+2 {
+		return nil, errors.New("wrong result string")
+	}
+
+	rows := make([][]uint8, TowerRows)
+	for i := range rows {
+		rows[i] = make([]uint8, width)
+		seen := make(map[uint8]bool, width)
+		for j := range rows[i] {
+			tile, err := strconv.ParseUint(elems[1+i*int(width)+j], 10, 8)
+			if err != nil {
+				return nil, err
+			}
+
+			if tile < 1 || tile > uint64(width) || seen[uint8(tile)] {
+				return nil, errors.New("wrong result string")
+			}
+
+			seen[uint8(tile)] = true
+			rows[i][j] = uint8(tile)
+		}
+	}
+
+	allocation := &TowerAllocation{
+		Difficulty: difficulty,
+		Rows:       rows,
+		LeftSeed:   elems[0],
+		RightSeed:  elems[len(elems)-1],
+		Result:     result,
+		ResultHash: hashResult(result),
+	}
+	return allocation, nil
+}
+
+func (l *Logic) NewTowerGame(allocation *TowerAllocation) *TowerGame {
+	return &TowerGame{
+		Allocation: allocation,
+	}
+}
+
+func (l *Logic) TowerStep(game *TowerGame, tile uint8) error {
+	safe, width, err := towerLayout(game.Allocation.Difficulty)
+	if err != nil {
+		return err
+	}
+
+	if game.Finished || len(game.Picks) >= TowerRows {
+		return errors.New("game is finished")
+	}
+
+	if tile < 1 || tile > width {
+		return errors.New("wrong tile")
+	}
+
+	row := game.Allocation.Rows[len(game.Picks)]
+	game.Picks = append(game.Picks, tile)
+	for _, trap := range row[:width-safe] {
+		if trap == tile {
+			game.Finished = true
+			game.Coefficient = 0
+			return nil
+		}
+	}
+
+	coefficients, err := l.GenerateTowerCoefficients(game.Allocation.Difficulty)
+	if err != nil {
+		return err
+	}
+
+	game.Coefficient = coefficients[len(game.Picks)-1]
+	return nil
+}
+
+func (l *Logic) TowerCashout(game *TowerGame) (float64, error) {
+	if game.Finished {
+		return 0, errors.New("game is finished")
+	}
+
+	if len(game.Picks) == 0 {
+		return 0, errors.New("nothing to cash out")
+	}
+
+	game.Finished = true
+	game.Won = true
+	return game.Coefficient, nil
+}
+
+// VerifyTowerGame replays the picked tiles row by row. A game that is not lost
+// after the last pick is treated as cashed out.
+func (l *Logic) VerifyTowerGame(result string, resultHash string, difficulty TowerDifficulty, picks []uint8) (*TowerGame, error) {
+	allocation, err := l.TowerAllocationFromString(result, difficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	if allocation.ResultHash != strings.ToLower(resultHash) {
+		return nil, errors.New("wrong result hash")
+	}
+
+	return l.playTowerGame(allocation, picks)
+}
+
+func (l *Logic) playTowerGame(allocation *TowerAllocation, picks []uint8) (*TowerGame, error) {
+	game := l.NewTowerGame(allocation)
+	for i, tile := range picks {
+		if err := l.TowerStep(game, tile); err != nil {
+			return nil, err
+		}
+
+		if game.Finished {
+			if i+1 < len(picks) {
+				return nil, errors.New("wrong pick sequence")
+			}
+			return game, nil
+		}
+	}
+
+	if _, err := l.TowerCashout(game); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
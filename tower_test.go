@@ -0,0 +1,87 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestLogic_GenerateTowerCoefficients(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	coefficients, err := instance.GenerateTowerCoefficients(TowerHard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coefficients) != TowerRows || !float64Compare(coefficients[:3], []float64{1.9, 3.8, 7.6}) {
+		t.Fatalf("expected [1.9 3.8 7.6 ...], but got %v", coefficients)
+	}
+
+	if _, err := instance.GenerateTowerCoefficients(100); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_TowerAllocationFromString(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	for difficulty := range towerLayouts {
+		allocation, err := instance.GenerateTowerAllocation(context.Background(), difficulty)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		restored, err := instance.TowerAllocationFromString(allocation.Result, difficulty)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, row := range allocation.Rows {
+			if !uint8Compare(row, restored.Rows[i]) {
+				t.Fatalf("expected \"%v\", but got \"%v\"", row, restored.Rows[i])
+			}
+		}
+	}
+}
+
+func TestLogic_TowerAllocationFromStringWrongRows(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	for _, result := range []string{
+		"a|1|1|2|1|1|2|1|2|1|2|1|2|1|2|1|2|1|2|b",
+		"a|1|3|2|1|1|2|1|2|1|2|1|2|1|2|1|2|1|2|b",
+		"a|0|2|2|1|1|2|1|2|1|2|1|2|1|2|1|2|1|2|b",
+	} {
+		if _, err := instance.TowerAllocationFromString(result, TowerHard); err == nil {
+			t.Fatalf("expected error for \"%s\", but got nil", result)
+		}
+	}
+}
+
+func TestLogic_VerifyTowerGame(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	result := "a|1|2|2|1|1|2|1|2|1|2|1|2|1|2|1|2|1|2|b"
+	resultHash := hashResult(result)
+
+	game, err := instance.VerifyTowerGame(result, resultHash, TowerHard, []uint8{2, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !game.Won || game.Coefficient != 3.8 {
+		t.Fatalf("expected win with 3.8, but got %v with %v", game.Won, game.Coefficient)
+	}
+
+	game, err = instance.VerifyTowerGame(result, resultHash, TowerHard, []uint8{2, 1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Won || !game.Finished || game.Coefficient != 0 {
+		t.Fatalf("expected loss, but got %v with %v", game.Won, game.Coefficient)
+	}
+
+	if _, err := instance.VerifyTowerGame(result, resultHash, TowerHard, []uint8{1, 1}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyTowerGame(result, resultHash, TowerHard, []uint8{3}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+	if _, err := instance.VerifyTowerGame(result, resultHash, TowerEasy, []uint8{1}); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
@@ -0,0 +1,151 @@
+package logic
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WithSignatureKey checks random.org signatures locally against key, the
+// public key of random.org, instead of calling verifySignature of random.org.
+func WithSignatureKey(key *rsa.PublicKey) Option {
+	return func(l *Logic) {
+		l.signatureKey = key
+	}
+}
+
+type localSignaturesKey struct{}
+
+// withLocalSignatures makes verifySignature fail instead of calling
+// random.org when there is no signature key.
+func withLocalSignatures(ctx context.Context) context.Context {
+	return context.WithValue(ctx, localSignaturesKey{}, true)
+}
+
+func (l *Logic) verifySignature(ctx context.Context, random string, signature string) error {
+	if l.signatureKey != nil {
+		sig, err := base64.StdEncoding.DecodeString(signature)
+		if err != nil {
+			return errors.New("wrong signature")
+		}
+
+		digest := sha512.Sum512([]byte(random))
+		if err := rsa.VerifyPKCS1v15(l.signatureKey, crypto.SHA512, digest[:], sig); err != nil {
+			return errors.New("wrong signature")
+		}
+		return nil
+	}
+
+	if local, _ := ctx.Value(localSignaturesKey{}).(bool); local {
+		return errors.New("no signature key")
+	}
+
+	authentic, err := l.api.VerifySignature(ctx, random, signature)
+	if err != nil {
+		return fmt.Errorf("random.org api error: %v", err)
+	}
+
+	if !authentic {
+		return errors.New("wrong signature")
+	}
+	return nil
+}
+
+// VerifyCrashCoefficient checks the random.org signature of the random object
+// and derives the coefficient from it.
+func (l *Logic) VerifyCrashCoefficient(ctx context.Context, random string, signature string) (*CrashCoefficient, error) {
+	coef, err := l.crashCoefficientFromRandom(random, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.verifySignature(ctx, random, signature); err != nil {
+		return nil, err
+	}
+	return coef, nil
+}
+
+func (l *Logic) crashCoefficientFromRandom(random string, signature string) (*CrashCoefficient, error) {
+	data := &decimalResponseRandom{}
+	if err := unmarshal([]byte(random), data); err != nil {
+		return nil, err
+	}
+
+	if data.Method != "generateSignedDecimalFractions" || data.DecimalPlaces != 3 || len(data.Data) != 1 || data.Data[0] < 0 || data.Data[0] >= 1 {
+		return nil, errors.New("wrong random")
+	}
+
+	coef := &CrashCoefficient{
+		Value:        crashFloor(data.Data[0]),
+		Random:       random,
+		Signature:    signature,
+		SerialNumber: data.SerialNumber,
+	}
+	return coef, nil
+}
+
+// VerifyDoubleNumber checks the random.org signature of the random object and
+// derives the number from it.
+func (l *Logic) VerifyDoubleNumber(ctx context.Context, random string, signature string) (*DoubleNumber, error) {
+	number, err := l.doubleNumberFromRandom(random, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.verifySignature(ctx, random, signature); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+func (l *Logic) doubleNumberFromRandom(random string, signature string) (*DoubleNumber, error) {
+	data := &integerResponseRandom{}
+	if err := unmarshal([]byte(random), data); err != nil {
+		return nil, err
+	}
+
+	if data.Method != "generateSignedIntegers" || data.Min != 0 || data.Max != 53 || len(data.Data) != 1 || data.Data[0] < 0 || data.Data[0] > 53 {
+		return nil, errors.New("wrong random")
+	}
+
+	number := &DoubleNumber{
+		Value:        data.Data[0],
+		Random:       random,
+		Signature:    signature,
+		SerialNumber: data.SerialNumber,
+	}
+	return number, nil
+}
+
+func (l *Logic) VerifyMinesAllocation(result string, resultHash string) (*MinesAllocation, error) {
+	allocation, err := l.MinesAllocationFromString(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if allocation.ResultHash != strings.ToLower(resultHash) {
+		return nil, errors.New("wrong result hash")
+	}
+	return allocation, nil
+}
+
+func (l *Logic) VerifyDiceNumber(result string, resultHash string) (*DiceNumber, error) {
+	number, err := l.DiceNumberFromString(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if number.ResultHash != strings.ToLower(resultHash) {
+		return nil, errors.New("wrong result hash")
+	}
+
+	if number.Value >= DiceLength {
+		return nil, errors.New("wrong result string")
+	}
+	return number, nil
+}
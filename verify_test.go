@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func withSignatureServer(t *testing.T, authenticity string) func() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":{"authenticity":` + authenticity + `},"id":1337}`))
+	}))
+	url := ApiUrl
+	ApiUrl = server.URL
+	return func() {
+		ApiUrl = url
+		server.Close()
+	}
+}
+
+func TestLogic_VerifyCrashCoefficient(t *testing.T) {
+	defer withSignatureServer(t, "true")()
+
+	instance := New(os.Getenv("API_KEY"))
+	random := `{"method":"generateSignedDecimalFractions","n":1,"decimalPlaces":3,"data":[0.5],"serialNumber":42}`
+	coef, err := instance.VerifyCrashCoefficient(context.Background(), random, "signature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coef.Value != crashFloor(0.5) || coef.SerialNumber != 42 {
+		t.Fatalf("expected %v #42, but got %v #%d", crashFloor(0.5), coef.Value, coef.SerialNumber)
+	}
+
+	if _, err := instance.VerifyCrashCoefficient(context.Background(), `{"decimalPlaces":8,"data":[0.5]}`, "signature"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_VerifyDoubleNumberWrongSignature(t *testing.T) {
+	defer withSignatureServer(t, "false")()
+
+	instance := New(os.Getenv("API_KEY"))
+	random := `{"method":"generateSignedIntegers","n":1,"min":0,"max":53,"data":[7],"serialNumber":42}`
+	if _, err := instance.VerifyDoubleNumber(context.Background(), random, "signature"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}
+
+func TestLogic_VerifyDoubleNumber(t *testing.T) {
+	defer withSignatureServer(t, "true")()
+
+	instance := New(os.Getenv("API_KEY"))
+	random := `{"method":"generateSignedIntegers","n":1,"min":0,"max":53,"data":[7],"serialNumber":42}`
+	number, err := instance.VerifyDoubleNumber(context.Background(), random, "signature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number.Value != 7 {
+		t.Fatalf("expected 7, but got %d", number.Value)
+	}
+}
+
+func TestLogic_VerifyDiceNumber(t *testing.T) {
+	instance := New(os.Getenv("API_KEY"))
+	result := "6bdp5eu5rtbwr87dnlxlpa2yj00598zlahnj|914655|fb79o8tqteia8s4on98imrrslpfpun7c9q31"
+	hash := "54504a11f613b1ec748e41e7efb5aefc37cb951676c6e5db576a308d5067f806b4a4a712148878c1c6fe20c056275a439f7ce8e10aa01a915bb1c665f5776ce6"
+
+	number, err := instance.VerifyDiceNumber(result, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number.Value != 914655 {
+		t.Fatalf("expected 914655, but got %d", number.Value)
+	}
+
+	if _, err := instance.VerifyDiceNumber(result, "wrong"); err == nil {
+		t.Fatalf("expected error but got nil")
+	}
+}